@@ -8,10 +8,12 @@ package mcp
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
 	"reflect"
+	"sync"
 	"sync/atomic"
 
 	"trpc.group/trpc-go/trpc-mcp-go/internal/errors"
@@ -62,6 +64,22 @@ type Connector interface {
 	UnregisterNotificationHandler(method string)
 }
 
+// ToolCallerWithOptions is implemented by clients accepting per-call options of tools/call,
+// e.g. WithProgressHandler.
+type ToolCallerWithOptions interface {
+	// CallToolWithOptions executes a specific tool with given parameters and per-call options.
+	CallToolWithOptions(ctx context.Context, req *CallToolRequest, options ...CallToolOption) (*CallToolResult, error)
+}
+
+// RequestHandlerRegistrar is implemented by clients handling requests initiated by the server,
+// e.g. sampling, roots and elicitation requests.
+type RequestHandlerRegistrar interface {
+	// RegisterRequestHandler registers a handler for requests initiated by the server.
+	RegisterRequestHandler(method string, handler RequestHandler)
+	// UnregisterRequestHandler removes a server request handler.
+	UnregisterRequestHandler(method string)
+}
+
 // SessionClient extends Connector with session management capabilities.
 // This is primarily for HTTP-based transports that support sessions.
 type SessionClient interface {
@@ -108,7 +126,12 @@ type Client struct {
 	initialized      bool                   // Whether the client is initialized.
 	requestID        atomic.Int64           // Atomic counter for request IDs.
 	capabilities     map[string]interface{} // Capabilities.
+	capabilitiesMu   sync.Mutex             // Mutex of the capabilities and roots state.
+	rootsAdvertised  bool                   // Whether the roots capability was advertised at initialization.
 	state            State                  // State.
+	roots            clientRoots            // Roots exposed to the server.
+	listRefresh      clientListRefresh      // Handlers of lists re-fetched after list_changed notifications.
+	interceptors     clientInterceptors     // Interceptors of requests and received notifications.
 	transportOptions []transportOption
 
 	// transport configuration.
@@ -131,7 +154,7 @@ func NewClient(serverURL string, clientInfo Implementation, options ...ClientOpt
 	// Create client.
 	client := &Client{
 		clientInfo:       clientInfo,
-		protocolVersion:  ProtocolVersion_2025_03_26, // Default compatible version.
+		protocolVersion:  ProtocolVersion_2025_06_18, // Default compatible version.
 		capabilities:     make(map[string]interface{}),
 		state:            StateDisconnected,
 		transportOptions: []transportOption{},
@@ -151,6 +174,19 @@ func NewClient(serverURL string, clientInfo Implementation, options ...ClientOpt
 		client.transport = newStreamableHTTPClientTransport(client.transportConfig, client.transportOptions...)
 	}
 
+	// Pass received notifications through the interceptors.
+	if t, ok := client.transport.(interceptorTransport); ok && len(client.interceptors) > 0 {
+		t.setInterceptors(client.interceptors)
+	}
+
+	// Answer roots/list requests if roots were configured.
+	if client.roots.enabled {
+		client.enableRoots()
+	}
+
+	// Re-fetch lists on list_changed notifications if configured.
+	client.enableListRefresh()
+
 	return client, nil
 }
 
@@ -208,6 +244,24 @@ func WithProtocolVersion(version string) ClientOption {
 	}
 }
 
+// WithRoots sets the roots exposed to the server and advertises the roots capability.
+// Roots can be updated later with SetRoots.
+func WithRoots(roots []Root) ClientOption {
+	return func(c *Client) {
+		c.roots.enabled = true
+		c.roots.set(roots)
+	}
+}
+
+// WithClientInterceptor adds interceptors wrapping every request sent by the client and every
+// notification received by it, whatever the transport. Interceptors run in the order they are added,
+// the first one being the outermost.
+func WithClientInterceptor(interceptors ...ClientInterceptor) ClientOption {
+	return func(c *Client) {
+		c.interceptors = append(c.interceptors, interceptors...)
+	}
+}
+
 // WithClientLogger sets the logger for the client transport.
 func WithClientLogger(logger Logger) ClientOption {
 	return func(c *Client) {
@@ -265,6 +319,11 @@ func WithHTTPReqHandlerOption(options ...HTTPReqHandlerOption) ClientOption {
 	}
 }
 
+// sendRequest sends a request to the server through the interceptors.
+func (c *Client) sendRequest(ctx context.Context, req *JSONRPCRequest) (*json.RawMessage, error) {
+	return c.interceptors.sendRequest(ctx, req, c.transport.sendRequest)
+}
+
 // GetState returns the current client state.
 func (c *Client) GetState() State {
 	return c.state
@@ -284,18 +343,24 @@ func (c *Client) Initialize(ctx context.Context, initReq *InitializeRequest) (*I
 
 	// Create request.
 	requestID := c.requestID.Add(1)
+	capabilities := c.getCapabilities()
 	req := newJSONRPCRequest(requestID, MethodInitialize, map[string]interface{}{
 		"protocolVersion": c.protocolVersion,
 		"clientInfo":      c.clientInfo,
-		"capabilities":    c.capabilities,
+		"capabilities":    capabilities,
 	})
+	_, rootsAdvertised := capabilities["roots"]
 
 	if initReq != nil && !isZeroStruct(initReq.Params) {
 		req.Params = initReq.Params
+		rootsAdvertised = initReq.Params.Capabilities.Roots != nil
 	}
+	c.capabilitiesMu.Lock()
+	c.rootsAdvertised = rootsAdvertised
+	c.capabilitiesMu.Unlock()
 
 	// Send request and wait for response
-	rawResp, err := c.transport.sendRequest(ctx, req)
+	rawResp, err := c.sendRequest(ctx, req)
 	if err != nil {
 		c.setState(StateDisconnected)
 		return nil, fmt.Errorf("initialization request failed: %w", err)
@@ -323,6 +388,11 @@ func (c *Client) Initialize(ctx context.Context, initReq *InitializeRequest) (*I
 		return nil, fmt.Errorf("failed to parse initialization response: %w", err)
 	}
 
+	// Subsequent HTTP requests carry the negotiated protocol version
+	if t, ok := c.transport.(*streamableHTTPClientTransport); ok {
+		t.setProtocolVersion(initResult.ProtocolVersion)
+	}
+
 	// Send initialized notification.
 	if err := c.SendInitialized(ctx); err != nil {
 		c.setState(StateDisconnected)
@@ -366,7 +436,7 @@ func (c *Client) ListTools(ctx context.Context, listToolsReq *ListToolsRequest)
 		Params: listToolsReq.Params,
 	}
 
-	rawResp, err := c.transport.sendRequest(ctx, req)
+	rawResp, err := c.sendRequest(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("list tools request failed: %v", err)
 	}
@@ -382,11 +452,20 @@ func (c *Client) ListTools(ctx context.Context, listToolsReq *ListToolsRequest)
 	}
 
 	// Parse response using specialized parser
-	return parseListToolsResultFromJSON(rawResp)
+	return parseListToolsResultFromJSON(rawResp, c.logger)
 }
 
 // CallTool calls a tool.
 func (c *Client) CallTool(ctx context.Context, callToolReq *CallToolRequest) (*CallToolResult, error) {
+	return c.CallToolWithOptions(ctx, callToolReq)
+}
+
+// CallToolWithOptions calls a tool with per-call options.
+func (c *Client) CallToolWithOptions(
+	ctx context.Context,
+	callToolReq *CallToolRequest,
+	options ...CallToolOption,
+) (*CallToolResult, error) {
 	// Check if initialized.
 	if !c.initialized {
 		return nil, errors.ErrNotInitialized
@@ -394,17 +473,33 @@ func (c *Client) CallTool(ctx context.Context, callToolReq *CallToolRequest) (*C
 
 	// Create request
 	requestID := c.requestID.Add(1)
+	params := callToolReq.Params
+
+	// Route progress notifications of this call to its handler, using the request ID as default token
+	callOptions := newCallToolOptions(options)
+	if callOptions.progressHandler != nil {
+		if t, ok := c.transport.(progressHandlerTransport); ok {
+			var token ProgressToken
+			params, token = withProgressToken(params, requestID)
+			unregister := t.registerProgressHandler(token, callOptions.progressHandler)
+			defer unregister()
+		}
+	}
+
 	req := &JSONRPCRequest{
 		JSONRPC: JSONRPCVersion,
 		ID:      requestID,
 		Request: Request{
 			Method: MethodToolsCall,
 		},
-		Params: callToolReq.Params,
+		Params: params,
 	}
 
-	rawResp, err := c.transport.sendRequest(ctx, req)
+	rawResp, err := c.sendRequest(ctx, req)
 	if err != nil {
+		if ctx.Err() != nil {
+			go c.notifyCancelled(requestID, ctx.Err().Error())
+		}
 		return nil, fmt.Errorf("tool call request failed: %w", err)
 	}
 
@@ -421,6 +516,17 @@ func (c *Client) CallTool(ctx context.Context, callToolReq *CallToolRequest) (*C
 	return parseCallToolResult(rawResp)
 }
 
+// notifyCancelled tells the server to stop processing a request the caller has given up on.
+func (c *Client) notifyCancelled(requestID interface{}, reason string) {
+	ctx, cancel := context.WithTimeout(context.Background(), cancelledNotificationTimeout)
+	defer cancel()
+
+	notification := NewCancelledNotification(requestID, reason)
+	if err := c.transport.sendNotification(ctx, notification); err != nil && c.logger != nil {
+		c.logger.Debugf("Failed to send cancelled notification for request %v: %v", requestID, err)
+	}
+}
+
 // Close closes the client connection and cleans up resources.
 func (c *Client) Close() error {
 	if c.transport != nil {
@@ -446,6 +552,8 @@ func (c *Client) TerminateSession(ctx context.Context) error {
 func (c *Client) RegisterNotificationHandler(method string, handler NotificationHandler) {
 	if httpTransport, ok := c.transport.(*streamableHTTPClientTransport); ok {
 		httpTransport.registerNotificationHandler(method, handler)
+	} else if sseTransport, ok := c.transport.(*sseClientTransport); ok {
+		sseTransport.registerNotificationHandler(method, handler)
 	} else if stdioTransport, ok := c.transport.(*stdioClientTransport); ok {
 		stdioTransport.registerNotificationHandler(method, handler)
 	}
@@ -455,11 +563,103 @@ func (c *Client) RegisterNotificationHandler(method string, handler Notification
 func (c *Client) UnregisterNotificationHandler(method string) {
 	if httpTransport, ok := c.transport.(*streamableHTTPClientTransport); ok {
 		httpTransport.unregisterNotificationHandler(method)
+	} else if sseTransport, ok := c.transport.(*sseClientTransport); ok {
+		sseTransport.unregisterNotificationHandler(method)
 	} else if stdioTransport, ok := c.transport.(*stdioClientTransport); ok {
 		stdioTransport.unregisterNotificationHandler(method)
 	}
 }
 
+// RegisterRequestHandler registers a handler for requests initiated by the server.
+// The handler's result is sent back to the server as the JSON-RPC response.
+func (c *Client) RegisterRequestHandler(method string, handler RequestHandler) {
+	if t, ok := c.transport.(requestHandlerTransport); ok {
+		t.registerRequestHandler(method, handler)
+	}
+}
+
+// UnregisterRequestHandler unregisters a handler for requests initiated by the server.
+func (c *Client) UnregisterRequestHandler(method string) {
+	if t, ok := c.transport.(requestHandlerTransport); ok {
+		t.unregisterRequestHandler(method)
+	}
+}
+
+// RegisterSamplingHandler registers a handler for sampling/createMessage requests.
+// The sampling capability is advertised if the handler is registered before Initialize.
+func (c *Client) RegisterSamplingHandler(handler SamplingHandler) {
+	c.setCapability("sampling", map[string]interface{}{})
+	c.RegisterRequestHandler(MethodSamplingCreateMessage, newSamplingRequestHandler(handler))
+}
+
+// RegisterElicitationHandler registers a handler for elicitation/create requests.
+// The elicitation capability is advertised if the handler is registered before Initialize.
+func (c *Client) RegisterElicitationHandler(handler ElicitationHandler) {
+	c.setCapability("elicitation", map[string]interface{}{})
+	c.RegisterRequestHandler(MethodElicitationCreate, newElicitationRequestHandler(handler))
+}
+
+// RegisterRootsListHandler registers a handler for roots/list requests.
+// The roots capability is advertised if the handler is registered before Initialize.
+func (c *Client) RegisterRootsListHandler(handler RootsListHandler) {
+	c.capabilitiesMu.Lock()
+	if _, ok := c.capabilities["roots"]; !ok {
+		c.capabilities["roots"] = map[string]interface{}{}
+	}
+	c.capabilitiesMu.Unlock()
+	c.RegisterRequestHandler(MethodRootsList, newRootsListRequestHandler(handler))
+}
+
+// setCapability sets a capability advertised at initialization.
+func (c *Client) setCapability(name string, capability interface{}) {
+	c.capabilitiesMu.Lock()
+	defer c.capabilitiesMu.Unlock()
+	c.capabilities[name] = capability
+}
+
+// getCapabilities returns a copy of the capabilities advertised at initialization.
+func (c *Client) getCapabilities() map[string]interface{} {
+	c.capabilitiesMu.Lock()
+	defer c.capabilitiesMu.Unlock()
+	capabilities := make(map[string]interface{}, len(c.capabilities))
+	for name, capability := range c.capabilities {
+		capabilities[name] = capability
+	}
+	return capabilities
+}
+
+// RegisterPingHandler registers a handler for ping requests.
+// Ping requests are answered automatically if no handler is registered.
+func (c *Client) RegisterPingHandler(handler PingHandler) {
+	c.RegisterRequestHandler(MethodPing, newPingRequestHandler(handler))
+}
+
+// SetRoots replaces the roots exposed to the server.
+// If the client is initialized and advertised the roots capability, the server is notified with
+// notifications/roots/list_changed.
+func (c *Client) SetRoots(ctx context.Context, roots []Root) error {
+	c.roots.set(roots)
+	c.capabilitiesMu.Lock()
+	enable := !c.roots.enabled
+	c.roots.enabled = true
+	notify := c.rootsAdvertised
+	c.capabilitiesMu.Unlock()
+	if enable {
+		c.enableRoots()
+	}
+
+	if !c.initialized || !notify {
+		return nil
+	}
+	return c.transport.sendNotification(ctx, NewRootsListChangedNotification())
+}
+
+// enableRoots advertises the roots capability and answers roots/list requests.
+func (c *Client) enableRoots() {
+	c.setCapability("roots", map[string]interface{}{"listChanged": true})
+	c.RegisterRequestHandler(MethodRootsList, newRootsListRequestHandler(c.roots.handleListRoots))
+}
+
 // ListPrompts lists available prompts.
 func (c *Client) ListPrompts(ctx context.Context, listPromptsReq *ListPromptsRequest) (*ListPromptsResult, error) {
 	// Check if initialized.
@@ -478,7 +678,7 @@ func (c *Client) ListPrompts(ctx context.Context, listPromptsReq *ListPromptsReq
 		Params: listPromptsReq.Params,
 	}
 
-	rawResp, err := c.transport.sendRequest(ctx, req)
+	rawResp, err := c.sendRequest(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("list prompts request failed: %w", err)
 	}
@@ -515,7 +715,7 @@ func (c *Client) GetPrompt(ctx context.Context, getPromptReq *GetPromptRequest)
 		Params: getPromptReq.Params,
 	}
 
-	rawResp, err := c.transport.sendRequest(ctx, req)
+	rawResp, err := c.sendRequest(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("get prompt request failed: %v", err)
 	}
@@ -552,7 +752,7 @@ func (c *Client) ListResources(ctx context.Context, listResourcesReq *ListResour
 		Params: listResourcesReq.Params,
 	}
 
-	rawResp, err := c.transport.sendRequest(ctx, req)
+	rawResp, err := c.sendRequest(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("list resources request failed: %v", err)
 	}
@@ -571,6 +771,110 @@ func (c *Client) ListResources(ctx context.Context, listResourcesReq *ListResour
 	return parseListResourcesResultFromJSON(rawResp)
 }
 
+// ListResourceTemplates lists available resource templates.
+func (c *Client) ListResourceTemplates(
+	ctx context.Context,
+	listTemplatesReq *ListResourceTemplatesRequest,
+) (*ListResourceTemplatesResult, error) {
+	// Check if initialized.
+	if !c.initialized {
+		return nil, fmt.Errorf("%w", errors.ErrNotInitialized)
+	}
+
+	// Create request.
+	requestID := c.requestID.Add(1)
+	req := &JSONRPCRequest{
+		JSONRPC: JSONRPCVersion,
+		ID:      requestID,
+		Request: Request{
+			Method: MethodResourcesTemplatesList,
+		},
+		Params: listTemplatesReq.Params,
+	}
+
+	rawResp, err := c.sendRequest(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("list resource templates request failed: %w", err)
+	}
+
+	// Check for error response
+	if isErrorResponse(rawResp) {
+		errResp, err := parseRawMessageToError(rawResp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse error response: %w", err)
+		}
+		return nil, fmt.Errorf("list resource templates error: %s (code: %d)",
+			errResp.Error.Message, errResp.Error.Code)
+	}
+
+	// Parse response using specialized parser
+	return parseListResourceTemplatesResultFromJSON(rawResp)
+}
+
+// ListAllTools lists available tools, following pagination cursors until the last page.
+func (c *Client) ListAllTools(ctx context.Context) ([]Tool, error) {
+	var tools []Tool
+	err := listAllPages(func(cursor Cursor) (Cursor, error) {
+		req := &ListToolsRequest{}
+		req.Params.Cursor = cursor
+		result, err := c.ListTools(ctx, req)
+		if err != nil {
+			return "", err
+		}
+		tools = append(tools, result.Tools...)
+		return result.NextCursor, nil
+	})
+	return tools, err
+}
+
+// ListAllPrompts lists available prompts, following pagination cursors until the last page.
+func (c *Client) ListAllPrompts(ctx context.Context) ([]Prompt, error) {
+	var prompts []Prompt
+	err := listAllPages(func(cursor Cursor) (Cursor, error) {
+		req := &ListPromptsRequest{}
+		req.Params.Cursor = cursor
+		result, err := c.ListPrompts(ctx, req)
+		if err != nil {
+			return "", err
+		}
+		prompts = append(prompts, result.Prompts...)
+		return result.NextCursor, nil
+	})
+	return prompts, err
+}
+
+// ListAllResources lists available resources, following pagination cursors until the last page.
+func (c *Client) ListAllResources(ctx context.Context) ([]Resource, error) {
+	var resources []Resource
+	err := listAllPages(func(cursor Cursor) (Cursor, error) {
+		req := &ListResourcesRequest{}
+		req.Params.Cursor = cursor
+		result, err := c.ListResources(ctx, req)
+		if err != nil {
+			return "", err
+		}
+		resources = append(resources, result.Resources...)
+		return result.NextCursor, nil
+	})
+	return resources, err
+}
+
+// ListAllResourceTemplates lists available resource templates, following pagination cursors until the last page.
+func (c *Client) ListAllResourceTemplates(ctx context.Context) ([]ResourceTemplate, error) {
+	var templates []ResourceTemplate
+	err := listAllPages(func(cursor Cursor) (Cursor, error) {
+		req := &ListResourceTemplatesRequest{}
+		req.Params.Cursor = cursor
+		result, err := c.ListResourceTemplates(ctx, req)
+		if err != nil {
+			return "", err
+		}
+		templates = append(templates, result.ResourceTemplates...)
+		return result.NextCursor, nil
+	})
+	return templates, err
+}
+
 // ReadResource reads a specific resource.
 func (c *Client) ReadResource(ctx context.Context, readResourceReq *ReadResourceRequest) (*ReadResourceResult, error) {
 	// Check if initialized.
@@ -589,7 +893,7 @@ func (c *Client) ReadResource(ctx context.Context, readResourceReq *ReadResource
 		Params: readResourceReq.Params,
 	}
 
-	rawResp, err := c.transport.sendRequest(ctx, req)
+	rawResp, err := c.sendRequest(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("read resource request failed: %v", err)
 	}
@@ -608,6 +912,133 @@ func (c *Client) ReadResource(ctx context.Context, readResourceReq *ReadResource
 	return parseReadResourceResultFromJSON(rawResp)
 }
 
+// Complete asks the server for completion values of a prompt argument or resource template variable.
+func (c *Client) Complete(ctx context.Context, completeReq *CompleteRequest) (*CompleteResult, error) {
+	// Check if initialized.
+	if !c.initialized {
+		return nil, errors.ErrNotInitialized
+	}
+
+	// Create request.
+	requestID := c.requestID.Add(1)
+	req := &JSONRPCRequest{
+		JSONRPC: JSONRPCVersion,
+		ID:      requestID,
+		Request: Request{
+			Method: MethodCompletionComplete,
+		},
+		Params: completeReq.Params,
+	}
+
+	rawResp, err := c.sendRequest(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("complete request failed: %w", err)
+	}
+
+	// Check for error response
+	if isErrorResponse(rawResp) {
+		errResp, err := parseRawMessageToError(rawResp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse error response: %w", err)
+		}
+		return nil, fmt.Errorf("complete error: %s (code: %d)",
+			errResp.Error.Message, errResp.Error.Code)
+	}
+
+	return parseCompleteResultFromJSON(rawResp)
+}
+
+// SetLoggingLevel sets the minimum level of log messages the server sends to this client.
+func (c *Client) SetLoggingLevel(ctx context.Context, level LoggingLevel) error {
+	// Check if initialized.
+	if !c.initialized {
+		return errors.ErrNotInitialized
+	}
+
+	// Create request.
+	requestID := c.requestID.Add(1)
+	req := newJSONRPCRequest(requestID, MethodLoggingSetLevel, map[string]interface{}{
+		"level": level,
+	})
+
+	rawResp, err := c.sendRequest(ctx, req)
+	if err != nil {
+		return fmt.Errorf("set logging level request failed: %w", err)
+	}
+
+	// Check for error response
+	if isErrorResponse(rawResp) {
+		errResp, err := parseRawMessageToError(rawResp)
+		if err != nil {
+			return fmt.Errorf("failed to parse error response: %w", err)
+		}
+		return fmt.Errorf("set logging level error: %s (code: %d)",
+			errResp.Error.Message, errResp.Error.Code)
+	}
+	return nil
+}
+
+// SubscribeResource subscribes to updates of a resource, the server then sends
+// notifications/resources/updated when the resource changes.
+func (c *Client) SubscribeResource(ctx context.Context, uri string) error {
+	// Check if initialized.
+	if !c.initialized {
+		return errors.ErrNotInitialized
+	}
+
+	// Create request.
+	requestID := c.requestID.Add(1)
+	req := newJSONRPCRequest(requestID, MethodResourcesSubscribe, map[string]interface{}{
+		"uri": uri,
+	})
+
+	rawResp, err := c.sendRequest(ctx, req)
+	if err != nil {
+		return fmt.Errorf("subscribe resource request failed: %w", err)
+	}
+
+	// Check for error response
+	if isErrorResponse(rawResp) {
+		errResp, err := parseRawMessageToError(rawResp)
+		if err != nil {
+			return fmt.Errorf("failed to parse error response: %w", err)
+		}
+		return fmt.Errorf("subscribe resource error: %s (code: %d)",
+			errResp.Error.Message, errResp.Error.Code)
+	}
+	return nil
+}
+
+// UnsubscribeResource cancels a subscription to updates of a resource.
+func (c *Client) UnsubscribeResource(ctx context.Context, uri string) error {
+	// Check if initialized.
+	if !c.initialized {
+		return errors.ErrNotInitialized
+	}
+
+	// Create request.
+	requestID := c.requestID.Add(1)
+	req := newJSONRPCRequest(requestID, MethodResourcesUnsubscribe, map[string]interface{}{
+		"uri": uri,
+	})
+
+	rawResp, err := c.sendRequest(ctx, req)
+	if err != nil {
+		return fmt.Errorf("unsubscribe resource request failed: %w", err)
+	}
+
+	// Check for error response
+	if isErrorResponse(rawResp) {
+		errResp, err := parseRawMessageToError(rawResp)
+		if err != nil {
+			return fmt.Errorf("failed to parse error response: %w", err)
+		}
+		return fmt.Errorf("unsubscribe resource error: %s (code: %d)",
+			errResp.Error.Message, errResp.Error.Code)
+	}
+	return nil
+}
+
 func isZeroStruct(x interface{}) bool {
 	return reflect.ValueOf(x).IsZero()
 }
@@ -0,0 +1,190 @@
+// Tencent is pleased to support the open source community by making trpc-mcp-go available.
+//
+// Copyright (C) 2025 Tencent.  All rights reserved.
+//
+// trpc-mcp-go is licensed under the Apache License Version 2.0.
+
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// RequestHandler handles a request initiated by the server.
+// The returned value is sent back to the server as the result of the JSON-RPC response,
+// a returned error is sent back as a JSON-RPC error.
+type RequestHandler func(ctx context.Context, req *JSONRPCRequest) (interface{}, error)
+
+// SamplingHandler handles sampling/createMessage requests from the server
+type SamplingHandler func(ctx context.Context, req *CreateMessageRequest) (*CreateMessageResult, error)
+
+// ElicitationHandler handles elicitation/create requests from the server
+type ElicitationHandler func(ctx context.Context, req *ElicitRequest) (*ElicitResult, error)
+
+// RootsListHandler handles roots/list requests from the server
+type RootsListHandler func(ctx context.Context, req *ListRootsRequest) (*ListRootsResult, error)
+
+// PingHandler handles ping requests from the server
+type PingHandler func(ctx context.Context) error
+
+// requestHandlerTransport is implemented by client transports that accept server-initiated requests
+type requestHandlerTransport interface {
+	// registerRequestHandler registers a handler for a server-initiated request method
+	registerRequestHandler(method string, handler RequestHandler)
+
+	// unregisterRequestHandler removes the handler for a server-initiated request method
+	unregisterRequestHandler(method string)
+}
+
+// requestHandlerError carries a JSON-RPC error code for a failed server-initiated request
+type requestHandlerError struct {
+	code    int
+	message string
+}
+
+// Error implements the error interface
+func (e *requestHandlerError) Error() string {
+	return e.message
+}
+
+// clientRequestHandlers dispatches server-initiated requests to registered handlers
+type clientRequestHandlers struct {
+	// Handlers keyed by method
+	handlers map[string]RequestHandler
+
+	// Mutex for handlers map
+	mu sync.RWMutex
+}
+
+// newClientRequestHandlers creates a handler registry that answers ping by default
+func newClientRequestHandlers() *clientRequestHandlers {
+	h := &clientRequestHandlers{
+		handlers: make(map[string]RequestHandler),
+	}
+	h.register(MethodPing, newPingRequestHandler(nil))
+	return h
+}
+
+// register registers a handler for a method
+func (h *clientRequestHandlers) register(method string, handler RequestHandler) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.handlers[method] = handler
+}
+
+// unregister removes the handler for a method
+func (h *clientRequestHandlers) unregister(method string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.handlers, method)
+}
+
+// handle dispatches a request and returns the JSON-RPC message to send back to the server
+func (h *clientRequestHandlers) handle(ctx context.Context, req *JSONRPCRequest) interface{} {
+	h.mu.RLock()
+	handler, ok := h.handlers[req.Method]
+	h.mu.RUnlock()
+
+	if !ok || handler == nil {
+		return newJSONRPCErrorResponse(req.ID, ErrCodeMethodNotFound,
+			fmt.Sprintf("method not found: %s", req.Method), nil)
+	}
+
+	result, err := handler(ctx, req)
+	if err != nil {
+		var handlerErr *requestHandlerError
+		if errors.As(err, &handlerErr) {
+			return newJSONRPCErrorResponse(req.ID, handlerErr.code, handlerErr.message, nil)
+		}
+		return newJSONRPCErrorResponse(req.ID, ErrCodeInternal, err.Error(), nil)
+	}
+	if result == nil {
+		result = map[string]interface{}{}
+	}
+
+	return &JSONRPCResponse{
+		JSONRPC: JSONRPCVersion,
+		ID:      req.ID,
+		Result:  result,
+	}
+}
+
+// parseRequestParams decodes the params of a server-initiated request into v
+func parseRequestParams(req *JSONRPCRequest, v interface{}) error {
+	if req.Params == nil {
+		return nil
+	}
+	data, err := json.Marshal(req.Params)
+	if err != nil {
+		return &requestHandlerError{code: ErrCodeInvalidParams, message: err.Error()}
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return &requestHandlerError{code: ErrCodeInvalidParams, message: fmt.Sprintf("invalid params: %v", err)}
+	}
+	return nil
+}
+
+// newSamplingRequestHandler adapts a SamplingHandler to a RequestHandler
+func newSamplingRequestHandler(handler SamplingHandler) RequestHandler {
+	return func(ctx context.Context, req *JSONRPCRequest) (interface{}, error) {
+		samplingReq := &CreateMessageRequest{}
+		samplingReq.Method = req.Method
+		if err := parseRequestParams(req, &samplingReq.Params); err != nil {
+			return nil, err
+		}
+		return handler(ctx, samplingReq)
+	}
+}
+
+// newElicitationRequestHandler adapts an ElicitationHandler to a RequestHandler
+func newElicitationRequestHandler(handler ElicitationHandler) RequestHandler {
+	return func(ctx context.Context, req *JSONRPCRequest) (interface{}, error) {
+		elicitReq := &ElicitRequest{}
+		elicitReq.Method = req.Method
+		if err := parseRequestParams(req, &elicitReq.Params); err != nil {
+			return nil, err
+		}
+		result, err := handler(ctx, elicitReq)
+		if err != nil {
+			return nil, err
+		}
+		if result != nil && result.Action == "" {
+			result.Action = ElicitActionCancel
+		}
+		return result, nil
+	}
+}
+
+// newRootsListRequestHandler adapts a RootsListHandler to a RequestHandler
+func newRootsListRequestHandler(handler RootsListHandler) RequestHandler {
+	return func(ctx context.Context, req *JSONRPCRequest) (interface{}, error) {
+		rootsReq := &ListRootsRequest{}
+		rootsReq.Method = req.Method
+		result, err := handler(ctx, rootsReq)
+		if err != nil {
+			return nil, err
+		}
+		if result != nil && result.Roots == nil {
+			result.Roots = []Root{}
+		}
+		return result, nil
+	}
+}
+
+// newPingRequestHandler adapts a PingHandler to a RequestHandler, a nil handler always succeeds
+func newPingRequestHandler(handler PingHandler) RequestHandler {
+	return func(ctx context.Context, req *JSONRPCRequest) (interface{}, error) {
+		if handler != nil {
+			if err := handler(ctx); err != nil {
+				return nil, err
+			}
+		}
+		return map[string]interface{}{}, nil
+	}
+}
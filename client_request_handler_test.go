@@ -0,0 +1,170 @@
+// Tencent is pleased to support the open source community by making trpc-mcp-go available.
+//
+// Copyright (C) 2025 Tencent.  All rights reserved.
+//
+// trpc-mcp-go is licensed under the Apache License Version 2.0.
+
+package mcp
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSamplingTestTool creates a tool that forwards its question to the client via sampling
+func newSamplingTestTool() (*Tool, toolHandler) {
+	tool := NewTool("ask", WithString("question"))
+	handler := func(ctx context.Context, req *CallToolRequest) (*CallToolResult, error) {
+		question, _ := req.Params.Arguments["question"].(string)
+		result, err := RequestSampling(ctx, &CreateMessageParams{
+			Messages: []SamplingMessage{
+				{Role: RoleUser, Content: NewTextContent(question)},
+			},
+			MaxTokens: 100,
+		})
+		if err != nil {
+			return nil, err
+		}
+		text, _ := result.Content.(TextContent)
+		return NewTextResult(result.Model + ": " + text.Text), nil
+	}
+	return tool, handler
+}
+
+// testSamplingHandler answers sampling requests by echoing the last message
+func testSamplingHandler(ctx context.Context, req *CreateMessageRequest) (*CreateMessageResult, error) {
+	last := req.Params.Messages[len(req.Params.Messages)-1]
+	text, _ := last.Content.(TextContent)
+	return &CreateMessageResult{
+		SamplingMessage: SamplingMessage{
+			Role:    RoleAssistant,
+			Content: NewTextContent("echo " + text.Text),
+		},
+		Model:      "test-model",
+		StopReason: StopReasonEndTurn,
+	}, nil
+}
+
+func TestClientRequestHandlers_Handle(t *testing.T) {
+	handlers := newClientRequestHandlers()
+	ctx := context.Background()
+
+	// Ping is answered by default
+	reply := handlers.handle(ctx, newJSONRPCRequest(1, MethodPing, nil))
+	resp, ok := reply.(*JSONRPCResponse)
+	require.True(t, ok)
+	assert.Equal(t, 1, resp.ID)
+
+	// Unknown methods are rejected
+	reply = handlers.handle(ctx, newJSONRPCRequest(2, "unknown/method", nil))
+	errResp, ok := reply.(*JSONRPCError)
+	require.True(t, ok)
+	assert.Equal(t, ErrCodeMethodNotFound, errResp.Error.Code)
+
+	// Handler errors become internal errors
+	handlers.register(MethodRootsList, func(ctx context.Context, req *JSONRPCRequest) (interface{}, error) {
+		return nil, errors.New("boom")
+	})
+	reply = handlers.handle(ctx, newJSONRPCRequest(3, MethodRootsList, nil))
+	errResp, ok = reply.(*JSONRPCError)
+	require.True(t, ok)
+	assert.Equal(t, ErrCodeInternal, errResp.Error.Code)
+	assert.Equal(t, "boom", errResp.Error.Message)
+
+	// Malformed params for typed handlers become invalid params errors
+	handlers.register(MethodSamplingCreateMessage, newSamplingRequestHandler(testSamplingHandler))
+	reply = handlers.handle(ctx, newJSONRPCRequest(4, MethodSamplingCreateMessage, map[string]interface{}{
+		"messages": "not-a-list",
+	}))
+	errResp, ok = reply.(*JSONRPCError)
+	require.True(t, ok)
+	assert.Equal(t, ErrCodeInvalidParams, errResp.Error.Code)
+}
+
+func TestClient_RegisterSamplingHandler(t *testing.T) {
+	server := NewServer("Test-Server", "1.0.0", WithServerPath("/mcp"))
+	server.RegisterTool(newSamplingTestTool())
+
+	httpServer := httptest.NewServer(server.HTTPHandler())
+	defer httpServer.Close()
+
+	client, err := NewClient(httpServer.URL+"/mcp", Implementation{Name: "Test-Client", Version: "1.0.0"})
+	require.NoError(t, err)
+	defer client.Close()
+	client.RegisterSamplingHandler(testSamplingHandler)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err = client.Initialize(ctx, &InitializeRequest{})
+	require.NoError(t, err)
+
+	result, err := client.CallTool(ctx, &CallToolRequest{
+		Params: CallToolParams{
+			Name:      "ask",
+			Arguments: map[string]interface{}{"question": "hello"},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	assert.Equal(t, "test-model: echo hello", result.Content[0].(TextContent).Text)
+}
+
+func TestSSEClient_RegisterSamplingHandler(t *testing.T) {
+	server := NewSSEServer("Test-SSE-Server", "1.0.0")
+	server.RegisterTool(newSamplingTestTool())
+
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	client, err := NewSSEClient(httpServer.URL+"/sse", Implementation{Name: "Test-Client", Version: "1.0.0"})
+	require.NoError(t, err)
+	defer client.Close()
+	client.RegisterSamplingHandler(testSamplingHandler)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err = client.Initialize(ctx, &InitializeRequest{})
+	require.NoError(t, err)
+
+	result, err := client.CallTool(ctx, &CallToolRequest{
+		Params: CallToolParams{
+			Name:      "ask",
+			Arguments: map[string]interface{}{"question": "hi"},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	assert.Equal(t, "test-model: echo hi", result.Content[0].(TextContent).Text)
+}
+
+func TestClient_RegisterHandlersDuringInitialize(t *testing.T) {
+	server := NewServer("Test-Server", "1.0.0", WithServerPath("/mcp"))
+	httpServer := httptest.NewServer(server.HTTPHandler())
+	defer httpServer.Close()
+
+	client, err := NewClient(httpServer.URL+"/mcp", Implementation{Name: "Test-Client", Version: "1.0.0"})
+	require.NoError(t, err)
+	defer client.Close()
+
+	// Capabilities may be registered while Initialize reads them
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		client.RegisterSamplingHandler(testSamplingHandler)
+		client.RegisterRootsListHandler(func(ctx context.Context, req *ListRootsRequest) (*ListRootsResult, error) {
+			return &ListRootsResult{}, nil
+		})
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err = client.Initialize(ctx, &InitializeRequest{})
+	require.NoError(t, err)
+	<-done
+	assert.Contains(t, client.getCapabilities(), "sampling")
+}
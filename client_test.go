@@ -87,7 +87,7 @@ func TestNewClient(t *testing.T) {
 	assert.NotNil(t, client)
 	assert.Equal(t, "Test-Client", client.clientInfo.Name)
 	assert.Equal(t, "1.0.0", client.clientInfo.Version)
-	assert.Equal(t, ProtocolVersion_2025_03_26, client.protocolVersion) // Update to current default version.
+	assert.Equal(t, ProtocolVersion_2025_06_18, client.protocolVersion) // Update to current default version.
 	assert.False(t, client.initialized)
 }
 
@@ -117,7 +117,7 @@ func TestClient_Initialize(t *testing.T) {
 	assert.NotNil(t, resp)
 	assert.Equal(t, "Test-Server", resp.ServerInfo.Name)
 	assert.Equal(t, "1.0.0", resp.ServerInfo.Version)
-	assert.Equal(t, ProtocolVersion_2025_03_26, resp.ProtocolVersion)
+	assert.Equal(t, ProtocolVersion_2025_06_18, resp.ProtocolVersion)
 	assert.NotNil(t, resp.Capabilities)
 
 	// Verify client state
@@ -167,17 +167,17 @@ func ExecuteSSETestTool(t *testing.T, c *mcp.Client, toolName string, args map[s
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// Register notification handlers for both progress and message notifications.
+	// Register notification handlers for message notifications.
 	handlers := collector.GetHandlers()
 	for method, handler := range handlers {
 		c.RegisterNotificationHandler(method, handler)
 	}
 
-	// Call tool with streaming method.
+	// Call tool with streaming method, progress notifications are delivered to the call's handler.
 	callToolReq := &mcp.CallToolRequest{}
 	callToolReq.Params.Name = toolName
 	callToolReq.Params.Arguments = args
-	result, err := c.CallTool(ctx, callToolReq)
+	result, err := c.CallToolWithOptions(ctx, callToolReq, mcp.WithProgressHandler(collector.ProgressHandler()))
 	require.NoError(t, err, "failed to call tool %s", toolName)
 	require.NotNil(t, result, "tool call stream result should not be nil")
 
@@ -212,12 +212,6 @@ func (nc *NotificationCollector) GetHandlers() map[string]mcp.NotificationHandle
 	// Create handler map.
 	handlers := make(map[string]mcp.NotificationHandler)
 
-	// Progress notification handler
-	handlers["notifications/progress"] = func(n *mcp.JSONRPCNotification) error {
-		nc.addNotification(n)
-		return nil
-	}
-
 	// Log notification handler
 	handlers["notifications/message"] = func(n *mcp.JSONRPCNotification) error {
 		nc.addNotification(n)
@@ -227,6 +221,16 @@ func (nc *NotificationCollector) GetHandlers() map[string]mcp.NotificationHandle
 	return handlers
 }
 
+// ProgressHandler returns the progress handler of a tool call.
+func (nc *NotificationCollector) ProgressHandler() mcp.ProgressHandler {
+	return func(n *mcp.ProgressNotification) {
+		nc.addNotification(&mcp.JSONRPCNotification{
+			JSONRPC:      mcp.JSONRPCVersion,
+			Notification: n.Notification,
+		})
+	}
+}
+
 // addNotification
 func (nc *NotificationCollector) addNotification(n *mcp.JSONRPCNotification) {
 	nc.mu.Lock()
@@ -100,8 +100,11 @@ func TestSSEProgress(t *testing.T) {
 			// Verify progress parameter.
 			progress, ok := notification.Params.AdditionalFields["progress"].(float64)
 			assert.True(t, ok, "Progress parameter should be float64")
-			expectedProgress := float64(i+1) / float64(steps)
-			assert.InDelta(t, expectedProgress, progress, 0.01, "Progress value for step %d should be %f", i+1, expectedProgress)
+			assert.Equal(t, float64(i+1), progress, "Progress value for step %d should be %d", i+1, i+1)
+
+			// Verify total and progress token parameters.
+			assert.Equal(t, float64(steps), notification.Params.AdditionalFields["total"], "Total should be the number of steps")
+			assert.NotNil(t, notification.Params.AdditionalFields["progressToken"], "Progress token should be set")
 
 			// Verify message parameter.
 			message, ok := notification.Params.AdditionalFields["message"].(string)
@@ -163,11 +163,9 @@ func handleSSEProgress(ctx context.Context, req *mcp.CallToolRequest) (*mcp.Call
 		case <-ctx.Done():
 			return nil, ctx.Err()
 		default:
-			// Calculate progress percentage.
-			progress := float64(i) / float64(steps)
 			// Send progress notification.
 			if sender, ok := mcp.GetNotificationSender(ctx); ok {
-				err := sender.SendProgress(progress, fmt.Sprintf("Step %d/%d", i, steps))
+				err := sender.SendProgress(float64(i), float64(steps), fmt.Sprintf("Step %d/%d", i, steps))
 				if err != nil {
 					return nil, fmt.Errorf("Failed to send progress notification: %v", err)
 				}
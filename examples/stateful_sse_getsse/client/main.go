@@ -12,7 +12,6 @@ import (
 	"log"
 	"os"
 	"os/signal"
-	"strings"
 	"syscall"
 	"time"
 
@@ -85,49 +84,14 @@ func handleNotification(notification *mcp.JSONRPCNotification) error {
 	return nil
 }
 
-// handleProgressNotification processes progress notifications.
-func handleProgressNotification(notification *mcp.JSONRPCNotification) error {
-	// Access parameters using AdditionalFields
-	paramsMap := notification.Params.AdditionalFields
-
-	// Extract progress value and message
-	progress, _ := paramsMap["progress"].(float64)
-	message, _ := paramsMap["message"].(string)
-
-	// Check if data is map[string]interface{}
-	if dataMap, ok := paramsMap["data"].(map[string]interface{}); ok {
-		notificationType, _ := dataMap["type"].(string)
-
-		log.Printf("Received progress notification [%s] (Type: %s): %+v", notification.Method, notificationType, dataMap)
-
-		// Process based on notificationType
-		switch notificationType {
-		case "process_progress":
-			// Parse step information from message
-			progressStr := fmt.Sprintf("%.2f%%", progress*100)
-
-			// Extract current step and total steps
-			currentStep := "?"
-			totalSteps := "?"
-
-			// Parse message in "Step 1/5" format
-			if parts := strings.Split(message, " "); len(parts) > 1 {
-				if stepParts := strings.Split(parts[1], "/"); len(stepParts) > 1 {
-					currentStep = stepParts[0]
-					totalSteps = stepParts[1]
-				}
-			}
-
-			log.Printf("  Process progress: %s (Step: %s/%s, Progress: %s)", message, currentStep, totalSteps, progressStr)
-		default:
-			log.Printf("  Unknown progress notification data type: %s", notificationType)
-		}
-	} else {
-		// No data field or other cases, display basic info
-		log.Printf("Received progress notification [%s]: Progress %.0f%%, %s", notification.Method, progress*100, message)
+// handleProgress processes the progress notifications of a tool call.
+func handleProgress(notification *mcp.ProgressNotification) {
+	params := notification.Params
+	if params.Total > 0 {
+		log.Printf("Received progress notification: %.0f/%.0f, %s", params.Progress, params.Total, params.Message)
+		return
 	}
-
-	return nil
+	log.Printf("Received progress notification: %.0f, %s", params.Progress, params.Message)
 }
 
 // initializeClient initializes the client
@@ -148,7 +112,6 @@ func initializeClient(ctx context.Context) (*mcp.Client, error) {
 
 	// Register notification handlers
 	mcpClient.RegisterNotificationHandler("notifications/message", handleNotification)
-	mcpClient.RegisterNotificationHandler("notifications/progress", handleProgressNotification)
 
 	// Initialize client
 	log.Printf("Initializing client...")
@@ -287,7 +250,7 @@ func handleChatOperations(ctx context.Context, client *mcp.Client) error {
 func handleDelayedOperations(ctx context.Context, client *mcp.Client) error {
 	// Call delayed response tool
 	log.Printf("Calling delayedResponse tool to experience streaming response...")
-	_, err := client.CallTool(ctx, &mcp.CallToolRequest{
+	_, err := client.CallToolWithOptions(ctx, &mcp.CallToolRequest{
 		Params: mcp.CallToolParams{
 			Name: "delayedResponse",
 			Arguments: map[string]interface{}{
@@ -295,7 +258,7 @@ func handleDelayedOperations(ctx context.Context, client *mcp.Client) error {
 				"delayMs": 500,
 			},
 		},
-	})
+	}, mcp.WithProgressHandler(handleProgress))
 	if err != nil {
 		return fmt.Errorf("delayed response tool call failed: %v", err)
 	}
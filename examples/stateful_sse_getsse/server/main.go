@@ -264,7 +264,7 @@ func handleDelayedResponse(ctx context.Context, req *mcp.CallToolRequest) (*mcp.
 
 		// Send progress notification
 		progress := float64(i) / float64(steps)
-		err := notificationSender.SendProgress(progress, fmt.Sprintf("Step %d/%d", i, steps))
+		err := notificationSender.SendProgress(float64(i), float64(steps), fmt.Sprintf("Step %d/%d", i, steps))
 		if err != nil {
 			log.Printf("Send progress notification failed: %v", err)
 		}
@@ -24,10 +24,6 @@ func handleNotifications(notification *mcp.JSONRPCNotification) error {
 		level, _ := notification.Params.AdditionalFields["level"].(string)
 		data, _ := notification.Params.AdditionalFields["data"].(string)
 		log.Printf("Received log message: [%s] %s", level, data)
-	case "notifications/progress":
-		progress, _ := notification.Params.AdditionalFields["progress"].(float64)
-		message, _ := notification.Params.AdditionalFields["message"].(string)
-		log.Printf("Received progress update: %.0f%% - %s", progress*100, message)
 	default:
 		log.Printf("Received other type of notification: %+v", notification.Params.AdditionalFields)
 	}
@@ -35,6 +31,11 @@ func handleNotifications(notification *mcp.JSONRPCNotification) error {
 	return nil
 }
 
+// handleProgress handles the progress notifications of a tool call.
+func handleProgress(notification *mcp.ProgressNotification) {
+	log.Printf("Received progress update: %.0f%% - %s", notification.Params.Progress*100, notification.Params.Message)
+}
+
 func main() {
 	// Print startup message.
 	log.Printf("Starting Stateless SSE No GET SSE mode client...")
@@ -72,7 +73,6 @@ func main() {
 	// Register notification handlers.
 	log.Printf("Registering notification handlers...")
 	mcpClient.RegisterNotificationHandler("notifications/message", handleNotifications)
-	mcpClient.RegisterNotificationHandler("notifications/progress", handleNotifications)
 
 	// Get available tools list.
 	log.Printf("Listing tools...")
@@ -114,7 +114,7 @@ func main() {
 
 	// Call multi-stage greeting tool (this will send notifications via SSE).
 	log.Printf("\nCalling multi-stage-greeting tool...")
-	multiStageResult, err := mcpClient.CallTool(ctx, &mcp.CallToolRequest{
+	multiStageResult, err := mcpClient.CallToolWithOptions(ctx, &mcp.CallToolRequest{
 		Params: mcp.CallToolParams{
 			Name: "multi-stage-greeting",
 			Arguments: map[string]interface{}{
@@ -122,7 +122,7 @@ func main() {
 				"stages": 5,
 			},
 		},
-	})
+	}, mcp.WithProgressHandler(handleProgress))
 	if err != nil {
 		log.Fatalf("Failed to call multi-stage greeting tool: %v", err)
 		return
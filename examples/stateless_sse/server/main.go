@@ -49,7 +49,7 @@ func handleMultiStageGreeting(ctx context.Context, req *mcp.CallToolRequest) (*m
 
 	// Send progress update.
 	sendProgress := func(progress float64, message string) {
-		err := notificationSender.SendProgress(progress, message)
+		err := notificationSender.SendProgress(progress, 1.0, message)
 		if err != nil {
 			log.Printf("Failed to send progress notification: %v", err)
 		}
@@ -8,6 +8,7 @@ package mcp
 
 import (
 	"context"
+	"fmt"
 )
 
 const (
@@ -39,11 +40,22 @@ type mcpHandler struct {
 
 	// Prompt manager
 	promptManager *promptManager
+
+	// Callback invoked when a client reports a change of its roots
+	rootsListChangedHandler RootsListChangedHandler
+
+	// Requests being processed, used to handle notifications/cancelled
+	inFlight *inFlightRequests
+
+	// Middlewares wrapping the handling of requests and notifications
+	middlewares []ServerMiddleware
 }
 
 // newMCPHandler creates an MCP protocol handler
 func newMCPHandler(options ...func(*mcpHandler)) *mcpHandler {
-	h := &mcpHandler{}
+	h := &mcpHandler{
+		inFlight: newInFlightRequests(),
+	}
 
 	// Apply options
 	for _, option := range options {
@@ -107,6 +119,20 @@ func withPromptManager(manager *promptManager) func(*mcpHandler) {
 	}
 }
 
+// withRootsListChangedHandler sets the callback for roots list changes
+func withRootsListChangedHandler(handler RootsListChangedHandler) func(*mcpHandler) {
+	return func(h *mcpHandler) {
+		h.rootsListChangedHandler = handler
+	}
+}
+
+// withMiddlewares adds middlewares wrapping the handling of requests and notifications
+func withMiddlewares(middlewares ...ServerMiddleware) func(*mcpHandler) {
+	return func(h *mcpHandler) {
+		h.middlewares = append(h.middlewares, middlewares...)
+	}
+}
+
 // Definition: request dispatch table type
 type requestHandlerFunc func(ctx context.Context, req *JSONRPCRequest, session Session) (JSONRPCMessage, error)
 
@@ -125,14 +151,35 @@ func (h *mcpHandler) requestDispatchTable() map[string]requestHandlerFunc {
 		MethodPromptsList:            h.handlePromptsList,
 		MethodPromptsGet:             h.handlePromptsGet,
 		MethodCompletionComplete:     h.handleCompletionComplete,
+		MethodLoggingSetLevel:        h.handleLoggingSetLevel,
 	}
 }
 
-// Refactored handleRequest
+// handleRequest handles a request through the middlewares
 func (h *mcpHandler) handleRequest(ctx context.Context, req *JSONRPCRequest, session Session) (JSONRPCMessage, error) {
-	dispatchTable := h.requestDispatchTable()
-	if handler, ok := dispatchTable[req.Method]; ok {
-		return handler(ctx, req, session)
+	// The initialize request must not be cancelled by the client
+	if req.Method != MethodInitialize {
+		var done func()
+		ctx, done = h.inFlight.begin(ctx, getSessionID(session), req.ID)
+		defer done()
+	}
+	return chainMiddlewares(h.dispatch, h.middlewares)(ctx, newRequestServerRequest(req, session))
+}
+
+// dispatch dispatches a request or notification to its handler
+func (h *mcpHandler) dispatch(ctx context.Context, serverReq *ServerRequest) (JSONRPCMessage, error) {
+	if serverReq.Notification {
+		notification := *serverReq.notification
+		if params, ok := serverReq.Params.(NotificationParams); ok {
+			notification.Params = params
+		}
+		return nil, h.dispatchNotification(ctx, &notification, serverReq.Session)
+	}
+
+	req := *serverReq.request
+	req.Params = serverReq.Params
+	if handler, ok := h.requestDispatchTable()[req.Method]; ok {
+		return handler(ctx, &req, serverReq.Session)
 	}
 	return newJSONRPCErrorResponse(req.ID, ErrCodeMethodNotFound, "method not found", nil), nil
 }
@@ -167,11 +214,15 @@ func (h *mcpHandler) handleResourcesTemplatesList(ctx context.Context, req *JSON
 }
 
 func (h *mcpHandler) handleResourcesSubscribe(ctx context.Context, req *JSONRPCRequest, session Session) (JSONRPCMessage, error) {
-	return h.resourceManager.handleSubscribe(ctx, req)
+	// Sessions of stateless servers only last for one request, so updates could never be delivered
+	if h.lifecycleManager != nil && h.lifecycleManager.isStateless {
+		return newJSONRPCErrorResponse(req.ID, ErrCodeMethodNotFound, "resource subscriptions are not supported in stateless mode", nil), nil
+	}
+	return h.resourceManager.handleSubscribe(ctx, req, session)
 }
 
 func (h *mcpHandler) handleResourcesUnsubscribe(ctx context.Context, req *JSONRPCRequest, session Session) (JSONRPCMessage, error) {
-	return h.resourceManager.handleUnsubscribe(ctx, req)
+	return h.resourceManager.handleUnsubscribe(ctx, req, session)
 }
 
 func (h *mcpHandler) handlePromptsList(ctx context.Context, req *JSONRPCRequest, session Session) (JSONRPCMessage, error) {
@@ -183,23 +234,69 @@ func (h *mcpHandler) handlePromptsGet(ctx context.Context, req *JSONRPCRequest,
 }
 
 func (h *mcpHandler) handleCompletionComplete(ctx context.Context, req *JSONRPCRequest, session Session) (JSONRPCMessage, error) {
-	return h.promptManager.handleCompletionComplete(ctx, req)
+	completeReq, errResp := parseCompleteParams(req)
+	if errResp != nil {
+		return errResp, nil
+	}
+	if completeReq.Params.Ref.Type == RefTypeResource {
+		return h.resourceManager.handleCompletionComplete(ctx, req, completeReq)
+	}
+	return h.promptManager.handleCompletionComplete(ctx, req, completeReq)
+}
+
+func (h *mcpHandler) handleLoggingSetLevel(ctx context.Context, req *JSONRPCRequest, session Session) (JSONRPCMessage, error) {
+	return handleSetLevel(req, session)
 }
 
-// handleNotification implements the handler interface's handleNotification method
+// handleNotification handles a notification through the middlewares
 func (h *mcpHandler) handleNotification(ctx context.Context, notification *JSONRPCNotification, session Session) error {
+	result, err := chainMiddlewares(h.dispatch, h.middlewares)(ctx, newNotificationServerRequest(notification, session))
+	if err != nil {
+		return err
+	}
+	// A middleware rejected the notification with an error response
+	if errResp, ok := result.(*JSONRPCError); ok {
+		return fmt.Errorf("notification %s rejected: %s", notification.Method, errResp.Error.Message)
+	}
+	return nil
+}
+
+// dispatchNotification dispatches a notification based on its method
+func (h *mcpHandler) dispatchNotification(ctx context.Context, notification *JSONRPCNotification, session Session) error {
 	// Dispatch notification based on method
 	switch notification.Method {
 	case MethodNotificationsInitialized:
 		return h.lifecycleManager.handleInitialized(ctx, notification, session)
+	case MethodNotificationsCancelled:
+		h.inFlight.handleCancelledNotification(notification, session)
+		return nil
+	case MethodNotificationsRootsListChanged:
+		return h.handleRootsListChanged(ctx)
 	default:
 		// Ignore unknown notifications
 		return nil
 	}
 }
 
+// handleRootsListChanged invokes the roots list changed callback.
+// The callback runs asynchronously because it usually sends a roots/list request back to the client.
+func (h *mcpHandler) handleRootsListChanged(ctx context.Context) error {
+	if h.rootsListChangedHandler != nil {
+		go h.rootsListChangedHandler(context.WithoutCancel(ctx))
+	}
+	return nil
+}
+
 // onSessionTerminated implements the sessionEventNotifier interface's OnSessionTerminated method
 func (h *mcpHandler) onSessionTerminated(sessionID string) {
 	// Notify lifecycle manager that session has terminated
 	h.lifecycleManager.onSessionTerminated(sessionID)
+
+	// Stop processing requests of the terminated session
+	h.inFlight.cancelSession(sessionID)
+
+	// Drop the resource subscriptions of the terminated session
+	if h.resourceManager != nil {
+		h.resourceManager.unsubscribeSession(sessionID)
+	}
 }
@@ -18,6 +18,9 @@ const (
 	// SessionIDHeader is the MCP session ID header
 	SessionIDHeader = "Mcp-Session-Id"
 
+	// ProtocolVersionHeader is the MCP protocol version header
+	ProtocolVersionHeader = "MCP-Protocol-Version"
+
 	// LastEventIDHeader is the SSE Last-Event-ID header
 	LastEventIDHeader = "Last-Event-ID"
 )
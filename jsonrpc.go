@@ -281,3 +281,18 @@ func parseRawMessageToError(raw *json.RawMessage) (*JSONRPCError, error) {
 	}
 	return &errResp, nil
 }
+
+// isBatchMessage checks if a raw message is a JSON-RPC batch (a JSON array)
+func isBatchMessage(raw json.RawMessage) bool {
+	for _, c := range raw {
+		switch c {
+		case ' ', '\t', '\r', '\n':
+			continue
+		case '[':
+			return true
+		default:
+			return false
+		}
+	}
+	return false
+}
@@ -53,8 +53,12 @@ func newLifecycleManager(serverInfo Implementation) *lifecycleManager {
 	return &lifecycleManager{
 		logger:                 GetDefaultLogger(), // Use default logger if not set.
 		serverInfo:             serverInfo,
-		defaultProtocolVersion: ProtocolVersion_2025_03_26,
-		supportedVersions:      []string{ProtocolVersion_2024_11_05, ProtocolVersion_2025_03_26},
+		defaultProtocolVersion: ProtocolVersion_2025_06_18,
+		supportedVersions: []string{
+			ProtocolVersion_2024_11_05,
+			ProtocolVersion_2025_03_26,
+			ProtocolVersion_2025_06_18,
+		},
 		capabilities: map[string]interface{}{
 			"tools": map[string]interface{}{
 				"listChanged": true,
@@ -112,7 +116,9 @@ func (m *lifecycleManager) withStatelessMode(isStateless bool) *lifecycleManager
 	return m
 }
 
-// updateCapabilities updates the server capability information
+// updateCapabilities updates the server capability information.
+// It runs for every initialize request, so new sessions see the capabilities matching the
+// tools, resources and prompts registered at that time.
 func (m *lifecycleManager) updateCapabilities() {
 	// Use map as an intermediate variable
 	capMap := map[string]interface{}{}
@@ -122,10 +128,16 @@ func (m *lifecycleManager) updateCapabilities() {
 		"listChanged": true,
 	}
 
-	// If there is a resource manager and resources are registered, add resource capabilities
-	if m.resourceManager != nil && len(m.resourceManager.getResources()) > 0 {
+	// Clients can always adjust the level of log messages sent by handlers
+	capMap["logging"] = map[string]interface{}{}
+
+	// If there is a resource manager and resources or templates are registered, add resource capabilities
+	if m.resourceManager != nil &&
+		(len(m.resourceManager.getResources()) > 0 || len(m.resourceManager.getTemplates()) > 0) {
 		capMap["resources"] = map[string]interface{}{
 			"listChanged": true,
+			// Updates are delivered to the subscribed sessions, which stateless mode does not keep
+			"subscribe": !m.isStateless,
 		}
 	}
 
@@ -136,6 +148,15 @@ func (m *lifecycleManager) updateCapabilities() {
 		}
 	}
 
+	// If prompt arguments or resource template variables can be completed, add completion capabilities
+	if (m.promptManager != nil && m.promptManager.hasCompletionProviders()) ||
+		(m.resourceManager != nil && m.resourceManager.hasCompletionProviders()) {
+		capMap["completions"] = map[string]interface{}{}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	// Preserve existing experimental features
 	if exp, ok := m.capabilities["experimental"]; ok {
 		capMap["experimental"] = exp
@@ -156,6 +177,7 @@ func (m *lifecycleManager) handleInitialize(ctx context.Context, req *JSONRPCReq
 	supportedVersion := m.selectSupportedVersion(protocolVersion)
 	m.logProtocolVersion(protocolVersion, supportedVersion)
 	m.saveSessionState(session, supportedVersion)
+	m.saveClientCapabilities(session, paramsMap["capabilities"])
 	m.updateCapabilities()
 	response := m.buildInitializeResponse(supportedVersion)
 	return response, nil
@@ -202,12 +224,28 @@ func (m *lifecycleManager) saveSessionState(session Session, protocolVersion str
 		m.sessionStates[session.GetID()] = false // Initialization started but not completed
 		m.mu.Unlock()
 		// Save protocol version to session data
-		session.SetData("protocolVersion", protocolVersion)
+		session.SetData(sessionDataKeyProtocolVersion, protocolVersion)
 	}
 }
 
+// saveClientCapabilities saves the capabilities advertised by the client into the session
+func (m *lifecycleManager) saveClientCapabilities(session Session, rawCapabilities interface{}) {
+	if session == nil || rawCapabilities == nil {
+		return
+	}
+	capabilities, err := parseClientCapabilities(rawCapabilities)
+	if err != nil {
+		m.logger.Debugf("Failed to parse client capabilities: %v", err)
+		return
+	}
+	session.SetData(sessionDataKeyClientCapabilities, capabilities)
+}
+
 // buildInitializeResponse creates the initialization response
 func (m *lifecycleManager) buildInitializeResponse(protocolVersion string) InitializeResult {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
 	return InitializeResult{
 		ProtocolVersion: protocolVersion,
 		ServerInfo: Implementation{
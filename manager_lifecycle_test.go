@@ -95,7 +95,7 @@ func TestHandleInitialize(t *testing.T) {
 				require.True(t, ok, "Expected InitializeResult but got different type")
 
 				if tc.protocolVersion == "2023-01-01" {
-					assert.Equal(t, ProtocolVersion_2025_03_26, initResp.ProtocolVersion)
+					assert.Equal(t, ProtocolVersion_2025_06_18, initResp.ProtocolVersion)
 				} else {
 					assert.Equal(t, tc.protocolVersion, initResp.ProtocolVersion)
 				}
@@ -109,7 +109,7 @@ func TestHandleInitialize(t *testing.T) {
 				require.True(t, ok)
 
 				if tc.protocolVersion == "2023-01-01" {
-					assert.Equal(t, ProtocolVersion_2025_03_26, storedVersion)
+					assert.Equal(t, ProtocolVersion_2025_06_18, storedVersion)
 				} else {
 					assert.Equal(t, tc.protocolVersion, storedVersion)
 				}
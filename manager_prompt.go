@@ -36,6 +36,15 @@ type promptManager struct {
 
 	// Track insertion order of prompts
 	promptsOrder []string
+
+	// Sequence number of the last registered prompt
+	lastSeq uint64
+
+	// Maximum number of prompts per prompts/list page, zero disables pagination
+	pageSize int
+
+	// Notifier of prompts/list changes
+	listChangedNotifier *listChangedNotifier
 }
 
 // newPromptManager creates a new prompt manager
@@ -48,6 +57,18 @@ func newPromptManager() *promptManager {
 	}
 }
 
+// withListChangedNotifier sets the notifier of prompts/list changes
+func (m *promptManager) withListChangedNotifier(notifier *listChangedNotifier) *promptManager {
+	m.listChangedNotifier = notifier
+	return m
+}
+
+// withPageSize sets the maximum number of prompts per prompts/list page
+func (m *promptManager) withPageSize(pageSize int) *promptManager {
+	m.pageSize = pageSize
+	return m
+}
+
 // registerPrompt registers a prompt
 func (m *promptManager) registerPrompt(prompt *Prompt, handler promptHandler) {
 	m.mu.Lock()
@@ -57,15 +78,82 @@ func (m *promptManager) registerPrompt(prompt *Prompt, handler promptHandler) {
 		return
 	}
 
-	if _, exists := m.prompts[prompt.Name]; !exists {
+	existing, exists := m.prompts[prompt.Name]
+	var seq uint64
+	if exists {
+		// Replacing a prompt keeps its position
+		seq = existing.seq
+	} else {
 		// Only add to order slice if it's a new prompt
 		m.promptsOrder = append(m.promptsOrder, prompt.Name)
+		m.lastSeq++
+		seq = m.lastSeq
 	}
 
 	m.prompts[prompt.Name] = &registeredPrompt{
 		Prompt:  prompt,
 		Handler: handler,
+		seq:     seq,
 	}
+	m.listChangedNotifier.notify(MethodNotificationsPromptsListChanged)
+}
+
+// unregisterPrompts removes prompts by names and returns the number of prompts removed
+func (m *promptManager) unregisterPrompts(names ...string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	unregisteredCount := 0
+	for _, name := range names {
+		if _, exists := m.prompts[name]; !exists {
+			continue
+		}
+
+		delete(m.prompts, name)
+		unregisteredCount++
+
+		// Remove from order slice
+		for i, promptName := range m.promptsOrder {
+			if promptName == name {
+				m.promptsOrder = append(m.promptsOrder[:i], m.promptsOrder[i+1:]...)
+				break
+			}
+		}
+	}
+
+	if unregisteredCount > 0 {
+		m.listChangedNotifier.notify(MethodNotificationsPromptsListChanged)
+	}
+	return unregisteredCount
+}
+
+// replacePrompts atomically replaces all registered prompts, which are then listed in the given order
+func (m *promptManager) replacePrompts(prompts []ServerPrompt) error {
+	registered := make(map[string]*registeredPrompt, len(prompts))
+	order := make([]string, 0, len(prompts))
+	for _, prompt := range prompts {
+		if prompt.Prompt == nil || prompt.Prompt.Name == "" || prompt.Handler == nil {
+			return fmt.Errorf("prompt and handler cannot be nil or unnamed")
+		}
+		if _, exists := registered[prompt.Prompt.Name]; exists {
+			return fmt.Errorf("duplicate prompt name: %s", prompt.Prompt.Name)
+		}
+		registered[prompt.Prompt.Name] = &registeredPrompt{Prompt: prompt.Prompt, Handler: prompt.Handler}
+		order = append(order, prompt.Prompt.Name)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Assign new sequence numbers in the given order, so that cursors follow the new order
+	for _, name := range order {
+		m.lastSeq++
+		registered[name].seq = m.lastSeq
+	}
+	m.prompts = registered
+	m.promptsOrder = order
+	m.listChangedNotifier.notify(MethodNotificationsPromptsListChanged)
+	return nil
 }
 
 // getPrompt retrieves a prompt
@@ -80,21 +168,38 @@ func (m *promptManager) getPrompt(name string) (*Prompt, bool) {
 	return registeredPrompt.Prompt, true
 }
 
-// getPrompts retrieves all prompts
+// getPrompts retrieves all prompts in registration order
 func (m *promptManager) getPrompts() []*Prompt {
+	prompts, _ := m.getOrderedPrompts()
+	return prompts
+}
+
+// getOrderedPrompts retrieves all prompts in registration order along with their registration sequence numbers
+func (m *promptManager) getOrderedPrompts() ([]*Prompt, []uint64) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
 	prompts := make([]*Prompt, 0, len(m.prompts))
-	for _, registeredPrompt := range m.prompts {
-		prompts = append(prompts, registeredPrompt.Prompt)
+	seqs := make([]uint64, 0, len(m.prompts))
+	for _, name := range m.promptsOrder {
+		if registeredPrompt, ok := m.prompts[name]; ok {
+			prompts = append(prompts, registeredPrompt.Prompt)
+			seqs = append(seqs, registeredPrompt.seq)
+		}
 	}
-	return prompts
+	return prompts, seqs
 }
 
 // handleListPrompts handles listing prompts requests
 func (m *promptManager) handleListPrompts(ctx context.Context, req *JSONRPCRequest) (JSONRPCMessage, error) {
-	prompts := m.getPrompts()
+	prompts, seqs := m.getOrderedPrompts()
+
+	// Select the requested page
+	start, end, nextCursor, err := paginate(seqs, getCursorParam(req), m.pageSize)
+	if err != nil {
+		return newJSONRPCErrorResponse(req.ID, ErrCodeInvalidParams, err.Error(), nil), nil
+	}
+	prompts = prompts[start:end]
 
 	// Convert []*mcp.Prompt to []mcp.Prompt for the result
 	resultPrompts := make([]Prompt, len(prompts))
@@ -105,6 +210,7 @@ func (m *promptManager) handleListPrompts(ctx context.Context, req *JSONRPCReque
 	result := &ListPromptsResult{
 		Prompts: resultPrompts,
 	}
+	result.NextCursor = nextCursor
 
 	return result, nil
 }
@@ -209,39 +315,48 @@ func (m *promptManager) handleGetPrompt(ctx context.Context, req *JSONRPCRequest
 	return result, nil
 }
 
-// Helper: Parse and validate parameters for CompletionComplete
-func parseCompletionCompleteParams(req *JSONRPCRequest) (promptName string, errResp JSONRPCMessage, ok bool) {
-	paramsMap, ok := req.Params.(map[string]interface{})
-	if !ok {
-		return "", newJSONRPCErrorResponse(req.ID, ErrCodeInvalidParams, errors.ErrInvalidParams.Error(), nil), false
-	}
-	ref, ok := paramsMap["ref"].(map[string]interface{})
-	if !ok {
-		return "", newJSONRPCErrorResponse(req.ID, ErrCodeInvalidParams, errors.ErrMissingParams.Error(), nil), false
-	}
-	refType, ok := ref["type"].(string)
-	if !ok || refType != "ref/prompt" {
-		return "", newJSONRPCErrorResponse(req.ID, ErrCodeInvalidParams, errors.ErrInvalidParams.Error(), nil), false
-	}
-	promptName, ok = ref["name"].(string)
-	if !ok {
-		return "", newJSONRPCErrorResponse(req.ID, ErrCodeInvalidParams, errors.ErrMissingParams.Error(), nil), false
+// hasCompletionProviders reports whether any prompt argument has a completion provider
+func (m *promptManager) hasCompletionProviders() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, registeredPrompt := range m.prompts {
+		for _, arg := range registeredPrompt.Prompt.Arguments {
+			if arg.Completion != nil {
+				return true
+			}
+		}
 	}
-	return promptName, nil, true
+	return false
 }
 
-// Refactored: handleCompletionComplete with logic unchanged, now using helpers
-func (m *promptManager) handleCompletionComplete(ctx context.Context, req *JSONRPCRequest) (JSONRPCMessage, error) {
-	promptName, errResp, ok := parseCompletionCompleteParams(req)
-	if !ok {
-		return errResp, nil
+// handleCompletionComplete completes an argument of a prompt
+func (m *promptManager) handleCompletionComplete(
+	ctx context.Context,
+	req *JSONRPCRequest,
+	completeReq *CompleteRequest,
+) (JSONRPCMessage, error) {
+	promptName := completeReq.Params.Ref.Name
+	prompt, exists := m.getPrompt(promptName)
+	if !exists {
+		return newJSONRPCErrorResponse(
+			req.ID,
+			ErrCodeInvalidParams,
+			fmt.Sprintf("%v: %s", errors.ErrPromptNotFound, promptName),
+			nil,
+		), nil
 	}
-	// Business logic remains unchanged, can be further split if needed
-	return m.handlePromptCompletion(ctx, promptName, req)
-}
 
-// Helper: Handle prompt completion business logic (can be further split if needed)
-func (m *promptManager) handlePromptCompletion(ctx context.Context, promptName string, req *JSONRPCRequest) (JSONRPCMessage, error) {
-	// Original handleCompletionComplete business logic placeholder
-	return newJSONRPCErrorResponse(req.ID, ErrCodeMethodNotFound, "not implemented", nil), nil
+	argName := completeReq.Params.Argument.Name
+	for _, arg := range prompt.Arguments {
+		if arg.Name == argName {
+			return complete(ctx, arg.Completion, completeReq.Params.Argument), nil
+		}
+	}
+	return newJSONRPCErrorResponse(
+		req.ID,
+		ErrCodeInvalidParams,
+		fmt.Sprintf("%v: unknown argument %s of prompt %s", errors.ErrInvalidParams, argName, promptName),
+		nil,
+	), nil
 }
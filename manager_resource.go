@@ -10,8 +10,8 @@ import (
 	"context"
 	"fmt"
 	"sync"
-	"time"
 
+	"github.com/yosida95/uritemplate/v3"
 	"trpc.group/trpc-go/trpc-mcp-go/internal/errors"
 )
 
@@ -38,14 +38,27 @@ type resourceManager struct {
 	// Mutex
 	mu sync.RWMutex
 
-	// Subscriber mapping table
-	subscribers map[string][]chan *JSONRPCNotification
+	// Subscribed session IDs keyed by resource URI
+	subscriptions map[string]map[string]struct{}
 
 	// Subscriber mutex
 	subMu sync.RWMutex
 
 	// Order of resources
 	resourcesOrder []string
+
+	// Order of resource templates
+	templatesOrder []string
+
+	// Sequence number of the last registered resource or template
+	lastSeq uint64
+
+	// Maximum number of items per resources/list and resources/templates/list page,
+	// zero disables pagination
+	pageSize int
+
+	// Notifier of resources/list and resources/templates/list changes
+	listChangedNotifier *listChangedNotifier
 }
 
 // newResourceManager creates a new resource manager
@@ -54,12 +67,24 @@ type resourceManager struct {
 // it is only enabled when the first resource is added.
 func newResourceManager() *resourceManager {
 	return &resourceManager{
-		resources:   make(map[string]*registeredResource),
-		templates:   make(map[string]*registerResourceTemplate),
-		subscribers: make(map[string][]chan *JSONRPCNotification),
+		resources:     make(map[string]*registeredResource),
+		templates:     make(map[string]*registerResourceTemplate),
+		subscriptions: make(map[string]map[string]struct{}),
 	}
 }
 
+// withListChangedNotifier sets the notifier of resources/list and resources/templates/list changes
+func (m *resourceManager) withListChangedNotifier(notifier *listChangedNotifier) *resourceManager {
+	m.listChangedNotifier = notifier
+	return m
+}
+
+// withPageSize sets the maximum number of items per resources/list and resources/templates/list page
+func (m *resourceManager) withPageSize(pageSize int) *resourceManager {
+	m.pageSize = pageSize
+	return m
+}
+
 // registerResource registers a resource
 func (m *resourceManager) registerResource(resource *Resource, handler resourceHandler) {
 	m.mu.Lock()
@@ -69,15 +94,24 @@ func (m *resourceManager) registerResource(resource *Resource, handler resourceH
 		return
 	}
 
-	if _, exists := m.resources[resource.URI]; !exists {
+	existing, exists := m.resources[resource.URI]
+	var seq uint64
+	if exists {
+		// Replacing a resource keeps its position
+		seq = existing.seq
+	} else {
 		// Only add to order slice if it's a new resource
 		m.resourcesOrder = append(m.resourcesOrder, resource.URI)
+		m.lastSeq++
+		seq = m.lastSeq
 	}
 
 	m.resources[resource.URI] = &registeredResource{
 		Resource: resource,
 		Handler:  handler,
+		seq:      seq,
 	}
+	m.listChangedNotifier.notify(MethodNotificationsResourcesListChanged)
 }
 
 // registerTemplate registers a resource template
@@ -101,14 +135,84 @@ func (m *resourceManager) registerTemplate(template *ResourceTemplate, handler r
 		return fmt.Errorf("template %s already exists", template.Name)
 	}
 
+	m.templatesOrder = append(m.templatesOrder, template.Name)
+	m.lastSeq++
 	m.templates[template.Name] = &registerResourceTemplate{
 		resourceTemplate: template,
 		Handler:          handler,
+		seq:              m.lastSeq,
 	}
+	m.listChangedNotifier.notify(MethodNotificationsResourcesListChanged)
 
 	return nil
 }
 
+// unregisterResources removes resources by URIs and returns the number of resources removed.
+// Subscriptions to the removed resources are dropped.
+func (m *resourceManager) unregisterResources(uris ...string) int {
+	m.mu.Lock()
+	unregisteredCount := 0
+	var removed []string
+	for _, uri := range uris {
+		if _, exists := m.resources[uri]; !exists {
+			continue
+		}
+
+		delete(m.resources, uri)
+		unregisteredCount++
+		removed = append(removed, uri)
+
+		// Remove from order slice
+		for i, resourceURI := range m.resourcesOrder {
+			if resourceURI == uri {
+				m.resourcesOrder = append(m.resourcesOrder[:i], m.resourcesOrder[i+1:]...)
+				break
+			}
+		}
+	}
+	m.mu.Unlock()
+
+	m.subMu.Lock()
+	for _, uri := range removed {
+		delete(m.subscriptions, uri)
+	}
+	m.subMu.Unlock()
+
+	if unregisteredCount > 0 {
+		m.listChangedNotifier.notify(MethodNotificationsResourcesListChanged)
+	}
+	return unregisteredCount
+}
+
+// unregisterTemplates removes resource templates by names and returns the number of templates removed
+func (m *resourceManager) unregisterTemplates(names ...string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	unregisteredCount := 0
+	for _, name := range names {
+		if _, exists := m.templates[name]; !exists {
+			continue
+		}
+
+		delete(m.templates, name)
+		unregisteredCount++
+
+		// Remove from order slice
+		for i, templateName := range m.templatesOrder {
+			if templateName == name {
+				m.templatesOrder = append(m.templatesOrder[:i], m.templatesOrder[i+1:]...)
+				break
+			}
+		}
+	}
+
+	if unregisteredCount > 0 {
+		m.listChangedNotifier.notify(MethodNotificationsResourcesListChanged)
+	}
+	return unregisteredCount
+}
+
 // getResource retrieves a resource
 func (m *resourceManager) getResource(uri string) (*Resource, bool) {
 	m.mu.RLock()
@@ -121,90 +225,120 @@ func (m *resourceManager) getResource(uri string) (*Resource, bool) {
 	return registeredResource.Resource, true
 }
 
-// getResources retrieves all resources
+// getResources retrieves all resources in registration order
 func (m *resourceManager) getResources() []*Resource {
+	resources, _ := m.getOrderedResources()
+	return resources
+}
+
+// getOrderedResources retrieves all resources in registration order along with their registration sequence numbers
+func (m *resourceManager) getOrderedResources() ([]*Resource, []uint64) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
 	resources := make([]*Resource, 0, len(m.resources))
-	for _, registeredResource := range m.resources {
-		resources = append(resources, registeredResource.Resource)
+	seqs := make([]uint64, 0, len(m.resources))
+	for _, uri := range m.resourcesOrder {
+		if registeredResource, ok := m.resources[uri]; ok {
+			resources = append(resources, registeredResource.Resource)
+			seqs = append(seqs, registeredResource.seq)
+		}
 	}
-	return resources
+	return resources, seqs
 }
 
-// getTemplates retrieves all resource templates
+// getTemplates retrieves all resource templates in registration order
 func (m *resourceManager) getTemplates() []*ResourceTemplate {
+	templates, _ := m.getOrderedTemplates()
+	return templates
+}
+
+// getOrderedTemplates retrieves all resource templates in registration order along with their
+// registration sequence numbers
+func (m *resourceManager) getOrderedTemplates() ([]*ResourceTemplate, []uint64) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
 	templates := make([]*ResourceTemplate, 0, len(m.templates))
-	for _, template := range m.templates {
-		templates = append(templates, template.resourceTemplate)
+	seqs := make([]uint64, 0, len(m.templates))
+	for _, name := range m.templatesOrder {
+		if template, ok := m.templates[name]; ok {
+			templates = append(templates, template.resourceTemplate)
+			seqs = append(seqs, template.seq)
+		}
 	}
-	return templates
+	return templates, seqs
 }
 
-// subscribe subscribes to resource updates
-func (m *resourceManager) subscribe(uri string) chan *JSONRPCNotification {
+// subscribe subscribes a session to updates of a resource
+func (m *resourceManager) subscribe(uri, sessionID string) {
 	m.subMu.Lock()
 	defer m.subMu.Unlock()
 
-	ch := make(chan *JSONRPCNotification, 10)
-	m.subscribers[uri] = append(m.subscribers[uri], ch)
-	return ch
+	sessions, ok := m.subscriptions[uri]
+	if !ok {
+		sessions = make(map[string]struct{})
+		m.subscriptions[uri] = sessions
+	}
+	sessions[sessionID] = struct{}{}
 }
 
-// unsubscribe cancels a subscription
-func (m *resourceManager) unsubscribe(uri string, ch chan *JSONRPCNotification) {
+// unsubscribe cancels the subscription of a session to a resource
+func (m *resourceManager) unsubscribe(uri, sessionID string) {
 	m.subMu.Lock()
 	defer m.subMu.Unlock()
 
-	subs := m.subscribers[uri]
-	for i, sub := range subs {
-		if sub == ch {
-			close(ch)
-			subs = append(subs[:i], subs[i+1:]...)
-			break
-		}
+	sessions := m.subscriptions[uri]
+	delete(sessions, sessionID)
+	if len(sessions) == 0 {
+		delete(m.subscriptions, uri)
 	}
-	if len(subs) == 0 {
-		delete(m.subscribers, uri)
-	} else {
-		m.subscribers[uri] = subs
+}
+
+// unsubscribeSession cancels all subscriptions of a session
+func (m *resourceManager) unsubscribeSession(sessionID string) {
+	m.subMu.Lock()
+	defer m.subMu.Unlock()
+
+	for uri, sessions := range m.subscriptions {
+		delete(sessions, sessionID)
+		if len(sessions) == 0 {
+			delete(m.subscriptions, uri)
+		}
 	}
 }
 
-// notifyUpdate notifies about resource updates
-func (m *resourceManager) notifyUpdate(uri string) {
+// getSubscribers gets the IDs of the sessions subscribed to a resource
+func (m *resourceManager) getSubscribers(uri string) []string {
 	m.subMu.RLock()
-	subs := m.subscribers[uri]
-	m.subMu.RUnlock()
-
-	// Create jsonrpcNotification params with correct struct type
-	notification := Notification{
-		Method: "notifications/resources/updated",
-		Params: NotificationParams{
-			AdditionalFields: map[string]interface{}{
-				"uri": uri,
-			},
-		},
-	}
+	defer m.subMu.RUnlock()
 
-	jsonrpcNotification := newJSONRPCNotification(notification)
+	sessionIDs := make([]string, 0, len(m.subscriptions[uri]))
+	for sessionID := range m.subscriptions[uri] {
+		sessionIDs = append(sessionIDs, sessionID)
+	}
+	return sessionIDs
+}
 
-	for _, ch := range subs {
-		select {
-		case ch <- jsonrpcNotification:
-		default:
-			// Skip this subscriber if the channel is full
-		}
+// isReadable reports whether uri is a registered resource or matches a resource template
+func (m *resourceManager) isReadable(uri string) bool {
+	if _, exists := m.getResource(uri); exists {
+		return true
 	}
+	template, _ := m.matchTemplate(uri)
+	return template != nil
 }
 
 // handleListResources handles listing resources requests
 func (m *resourceManager) handleListResources(ctx context.Context, req *JSONRPCRequest) (JSONRPCMessage, error) {
-	resources := m.getResources()
+	resources, seqs := m.getOrderedResources()
+
+	// Select the requested page
+	start, end, nextCursor, err := paginate(seqs, getCursorParam(req), m.pageSize)
+	if err != nil {
+		return newJSONRPCErrorResponse(req.ID, ErrCodeInvalidParams, err.Error(), nil), nil
+	}
+	resources = resources[start:end]
 
 	// Convert []*mcp.Resource to []mcp.Resource for the result
 	resultResources := make([]Resource, len(resources))
@@ -216,6 +350,7 @@ func (m *resourceManager) handleListResources(ctx context.Context, req *JSONRPCR
 	result := ListResourcesResult{
 		Resources: resultResources,
 	}
+	result.NextCursor = nextCursor
 
 	// Return response
 	return result, nil
@@ -235,17 +370,6 @@ func (m *resourceManager) handleReadResource(ctx context.Context, req *JSONRPCRe
 		return newJSONRPCErrorResponse(req.ID, ErrCodeInvalidParams, errors.ErrMissingParams.Error(), nil), nil
 	}
 
-	// Get resource
-	registeredResource, exists := m.resources[uri]
-	if !exists {
-		return newJSONRPCErrorResponse(
-			req.ID,
-			ErrCodeMethodNotFound,
-			fmt.Sprintf("%v: %s", errors.ErrResourceNotFound, uri),
-			nil,
-		), nil
-	}
-
 	// Create resource read request
 	readReq := &ReadResourceRequest{
 		Params: struct {
@@ -256,23 +380,124 @@ func (m *resourceManager) handleReadResource(ctx context.Context, req *JSONRPCRe
 		},
 	}
 
-	// Call resource handler
-	content, err := registeredResource.Handler(ctx, readReq)
+	// Static resources take precedence over templates
+	m.mu.RLock()
+	registeredResource, exists := m.resources[uri]
+	m.mu.RUnlock()
+	if exists {
+		// Call resource handler
+		content, err := registeredResource.Handler(ctx, readReq)
+		if err != nil {
+			return newJSONRPCErrorResponse(req.ID, ErrCodeInternal, err.Error(), nil), nil
+		}
+
+		// Create result
+		result := ReadResourceResult{
+			Contents: []ResourceContents{content},
+		}
+
+		return result, nil
+	}
+
+	// Fall back to the resource templates matching the URI
+	template, arguments := m.matchTemplate(uri)
+	if template == nil {
+		return newJSONRPCErrorResponse(
+			req.ID,
+			ErrCodeMethodNotFound,
+			fmt.Sprintf("%v: %s", errors.ErrResourceNotFound, uri),
+			nil,
+		), nil
+	}
+	readReq.Params.Arguments = arguments
+
+	// Call resource template handler
+	contents, err := template.Handler(ctx, readReq)
 	if err != nil {
 		return newJSONRPCErrorResponse(req.ID, ErrCodeInternal, err.Error(), nil), nil
 	}
 
-	// Create result
 	result := ReadResourceResult{
-		Contents: []ResourceContents{content},
+		Contents: contents,
+	}
+	if result.Contents == nil {
+		result.Contents = []ResourceContents{}
 	}
 
 	return result, nil
 }
 
+// matchTemplate finds the resource template matching uri and extracts the template variables.
+// When several templates match, the one with the most literal characters wins, so that
+// "file:///repos/{id}" takes precedence over "file:///{+path}", ties are broken by registration order.
+func (m *resourceManager) matchTemplate(uri string) (*registerResourceTemplate, map[string]interface{}) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var (
+		best          *registerResourceTemplate
+		bestValues    uritemplate.Values
+		bestLiteralsN int
+	)
+	for _, name := range m.templatesOrder {
+		template, ok := m.templates[name]
+		if !ok || template.Handler == nil {
+			continue
+		}
+		values := template.resourceTemplate.URITemplate.Match(uri)
+		if values == nil {
+			continue
+		}
+		literalsN := templateLiteralLength(template.resourceTemplate.URITemplate.Raw())
+		if best == nil || literalsN > bestLiteralsN {
+			best, bestValues, bestLiteralsN = template, values, literalsN
+		}
+	}
+	if best == nil {
+		return nil, nil
+	}
+
+	arguments := make(map[string]interface{}, len(bestValues))
+	for name, value := range bestValues {
+		switch value.T {
+		case uritemplate.ValueTypeList:
+			arguments[name] = value.List()
+		case uritemplate.ValueTypeKV:
+			arguments[name] = value.KV()
+		default:
+			arguments[name] = value.String()
+		}
+	}
+	return best, arguments
+}
+
+// templateLiteralLength counts the characters of a URI template outside of its expressions
+func templateLiteralLength(raw string) int {
+	n := 0
+	inExpression := false
+	for _, r := range raw {
+		switch {
+		case r == '{':
+			inExpression = true
+		case r == '}':
+			inExpression = false
+		case !inExpression:
+			n++
+		}
+	}
+	return n
+}
+
 // handleListTemplates handles listing templates requests
 func (m *resourceManager) handleListTemplates(ctx context.Context, req *JSONRPCRequest) (JSONRPCMessage, error) {
-	templates := m.getTemplates()
+	templates, seqs := m.getOrderedTemplates()
+
+	// Select the requested page
+	start, end, nextCursor, err := paginate(seqs, getCursorParam(req), m.pageSize)
+	if err != nil {
+		return newJSONRPCErrorResponse(req.ID, ErrCodeInvalidParams, err.Error(), nil), nil
+	}
+	templates = templates[start:end]
 
 	// Convert []*mcp.ResourceTemplate to []mcp.ResourceTemplate for the result
 	resultTemplates := make([]ResourceTemplate, len(templates))
@@ -280,69 +505,110 @@ func (m *resourceManager) handleListTemplates(ctx context.Context, req *JSONRPCR
 		resultTemplates[i] = *template
 	}
 
-	// Use map structure since ListResourceTemplatesResult might not be defined
-	result := map[string]interface{}{
-		"resourceTemplates": resultTemplates,
+	result := ListResourceTemplatesResult{
+		ResourceTemplates: resultTemplates,
 	}
+	result.NextCursor = nextCursor
 
 	return result, nil
 }
 
+// hasCompletionProviders reports whether any resource template variable has a completion provider
+func (m *resourceManager) hasCompletionProviders() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, template := range m.templates {
+		if len(template.resourceTemplate.completions) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// handleCompletionComplete completes a variable of a resource template
+func (m *resourceManager) handleCompletionComplete(
+	ctx context.Context,
+	req *JSONRPCRequest,
+	completeReq *CompleteRequest,
+) (JSONRPCMessage, error) {
+	uri := completeReq.Params.Ref.URI
+	var template *ResourceTemplate
+	for _, t := range m.getTemplates() {
+		if t.URITemplate.Raw() == uri {
+			template = t
+			break
+		}
+	}
+	if template == nil {
+		return newJSONRPCErrorResponse(req.ID, ErrCodeInvalidParams,
+			fmt.Sprintf("resource template %s not found", uri), nil), nil
+	}
+
+	argName := completeReq.Params.Argument.Name
+	for _, varName := range template.URITemplate.Varnames() {
+		if varName == argName {
+			return complete(ctx, template.completions[argName], completeReq.Params.Argument), nil
+		}
+	}
+	return newJSONRPCErrorResponse(req.ID, ErrCodeInvalidParams,
+		fmt.Sprintf("%v: unknown variable %s of resource template %s", errors.ErrInvalidParams, argName, uri), nil), nil
+}
+
 // handleSubscribe handles subscription requests
-func (m *resourceManager) handleSubscribe(ctx context.Context, req *JSONRPCRequest) (JSONRPCMessage, error) {
-	// Convert params to map for easier access
-	paramsMap, ok := req.Params.(map[string]interface{})
-	if !ok {
-		return newJSONRPCErrorResponse(req.ID, ErrCodeInvalidParams, errors.ErrInvalidParams.Error(), nil), nil
+func (m *resourceManager) handleSubscribe(ctx context.Context, req *JSONRPCRequest, session Session) (JSONRPCMessage, error) {
+	uri, errResp := parseSubscriptionURI(req)
+	if errResp != nil {
+		return errResp, nil
 	}
 
-	// Get resource URI from parameters
-	uri, ok := paramsMap["uri"].(string)
-	if !ok {
-		return newJSONRPCErrorResponse(req.ID, ErrCodeInvalidParams, errors.ErrMissingParams.Error(), nil), nil
+	// Updates are delivered to the session, so subscriptions require one
+	if session == nil {
+		return newJSONRPCErrorResponse(req.ID, ErrCodeInvalidRequest, "resource subscriptions require a session", nil), nil
 	}
 
 	// Check if resource exists
-	_, exists := m.getResource(uri)
-	if !exists {
-		return newJSONRPCErrorResponse(req.ID, ErrCodeMethodNotFound, fmt.Sprintf("resource %s not found", uri), nil), nil
+	if !m.isReadable(uri) {
+		return newJSONRPCErrorResponse(req.ID, ErrCodeMethodNotFound,
+			fmt.Sprintf("%v: %s", errors.ErrResourceNotFound, uri), nil), nil
 	}
 
-	// subscribe to resource updates
-	_ = m.subscribe(uri) // We're not using the channel directly in the response
+	m.subscribe(uri, session.GetID())
+	return map[string]interface{}{}, nil
+}
 
-	// Return success response
-	result := map[string]interface{}{
-		"uri":           uri,
-		"subscribeTime": time.Now().UTC().Format(time.RFC3339),
+// handleUnsubscribe handles unsubscription requests
+func (m *resourceManager) handleUnsubscribe(ctx context.Context, req *JSONRPCRequest, session Session) (JSONRPCMessage, error) {
+	uri, errResp := parseSubscriptionURI(req)
+	if errResp != nil {
+		return errResp, nil
 	}
 
-	return result, nil
+	if session != nil {
+		m.unsubscribe(uri, session.GetID())
+	}
+	return map[string]interface{}{}, nil
 }
 
-// handleUnsubscribe handles unsubscription requests
-func (m *resourceManager) handleUnsubscribe(ctx context.Context, req *JSONRPCRequest) (JSONRPCMessage, error) {
+// parseSubscriptionURI gets the resource URI of a subscribe or unsubscribe request
+func parseSubscriptionURI(req *JSONRPCRequest) (string, JSONRPCMessage) {
 	// Convert params to map for easier access
 	paramsMap, ok := req.Params.(map[string]interface{})
 	if !ok {
-		return newJSONRPCErrorResponse(req.ID, ErrCodeInvalidParams, errors.ErrInvalidParams.Error(), nil), nil
+		return "", newJSONRPCErrorResponse(req.ID, ErrCodeInvalidParams, errors.ErrInvalidParams.Error(), nil)
 	}
 
 	// Get resource URI from parameters
 	uri, ok := paramsMap["uri"].(string)
-	if !ok {
-		return newJSONRPCErrorResponse(req.ID, ErrCodeInvalidParams, errors.ErrMissingParams.Error(), nil), nil
-	}
-
-	// unsubscribe from resource updates
-	// Note: In real implementation, you need to locate the specific channel to unsubscribe
-	// This is just a simplified implementation
-
-	// Return success response
-	result := map[string]interface{}{
-		"uri":             uri,
-		"unsubscribeTime": time.Now().UTC().Format(time.RFC3339),
+	if !ok || uri == "" {
+		return "", newJSONRPCErrorResponse(req.ID, ErrCodeInvalidParams, errors.ErrMissingParams.Error(), nil)
 	}
+	return uri, nil
+}
 
-	return result, nil
+// newResourceUpdatedNotification creates a notifications/resources/updated notification
+func newResourceUpdatedNotification(uri string) *JSONRPCNotification {
+	return NewJSONRPCNotificationFromMap(MethodNotificationsResourcesUpdated, map[string]interface{}{
+		"uri": uri,
+	})
 }
@@ -0,0 +1,158 @@
+// Tencent is pleased to support the open source community by making trpc-mcp-go available.
+//
+// Copyright (C) 2025 Tencent.  All rights reserved.
+//
+// trpc-mcp-go is licensed under the Apache License Version 2.0.
+
+package mcp
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceManager_ReadTemplate(t *testing.T) {
+	manager := newResourceManager()
+
+	// templateHandler echoes the template name and its arguments
+	templateHandler := func(name string) resourceTemplateHandler {
+		return func(ctx context.Context, req *ReadResourceRequest) ([]ResourceContents, error) {
+			var args []string
+			for _, key := range []string{"path", "owner", "repo"} {
+				if value, ok := req.Params.Arguments[key]; ok {
+					args = append(args, key+"="+value.(string))
+				}
+			}
+			return []ResourceContents{TextResourceContents{
+				URI:  req.Params.URI,
+				Text: name + ":" + strings.Join(args, ","),
+			}}, nil
+		}
+	}
+	require.NoError(t, manager.registerTemplate(NewResourceTemplate("file:///{+path}", "files"), templateHandler("files")))
+	require.NoError(t, manager.registerTemplate(
+		NewResourceTemplate("file:///repos/{owner}/{repo}", "repos"), templateHandler("repos")))
+	manager.registerResource(&Resource{URI: "file:///repos/trpc-group/static", Name: "static"},
+		func(ctx context.Context, req *ReadResourceRequest) (ResourceContents, error) {
+			return TextResourceContents{URI: req.Params.URI, Text: "static"}, nil
+		})
+
+	read := func(uri string) JSONRPCMessage {
+		resp, err := manager.handleReadResource(context.Background(), newJSONRPCRequest(1, MethodResourcesRead,
+			map[string]interface{}{"uri": uri}))
+		require.NoError(t, err)
+		return resp
+	}
+	readText := func(uri string) string {
+		result, ok := read(uri).(ReadResourceResult)
+		require.True(t, ok)
+		require.Len(t, result.Contents, 1)
+		return result.Contents[0].(TextResourceContents).Text
+	}
+
+	// Static resources take precedence over templates
+	assert.Equal(t, "static", readText("file:///repos/trpc-group/static"))
+
+	// The most specific template wins regardless of registration order
+	assert.Equal(t, "repos:owner=trpc-group,repo=trpc-mcp-go", readText("file:///repos/trpc-group/trpc-mcp-go"))
+	assert.Equal(t, "files:path=README.md", readText("file:///README.md"))
+	assert.Equal(t, "files:path=repos/trpc-group", readText("file:///repos/trpc-group"))
+
+	// URIs matching no template are not found
+	errResp, ok := read("http://example.com/").(*JSONRPCError)
+	require.True(t, ok)
+	assert.Equal(t, ErrCodeMethodNotFound, errResp.Error.Code)
+}
+
+func TestResourceManager_Subscriptions(t *testing.T) {
+	manager := newResourceManager()
+	manager.registerResource(&Resource{URI: "file:///a", Name: "a"}, nil)
+	session := newSession()
+
+	subscribe := func(uri string) JSONRPCMessage {
+		resp, err := manager.handleSubscribe(context.Background(), newJSONRPCRequest(1, MethodResourcesSubscribe,
+			map[string]interface{}{"uri": uri}), session)
+		require.NoError(t, err)
+		return resp
+	}
+
+	assert.Equal(t, map[string]interface{}{}, subscribe("file:///a"))
+	assert.Equal(t, []string{session.GetID()}, manager.getSubscribers("file:///a"))
+
+	// Unknown resources cannot be subscribed to
+	_, ok := subscribe("file:///missing").(*JSONRPCError)
+	assert.True(t, ok)
+
+	resp, err := manager.handleUnsubscribe(context.Background(), newJSONRPCRequest(2, MethodResourcesUnsubscribe,
+		map[string]interface{}{"uri": "file:///a"}), session)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{}, resp)
+	assert.Empty(t, manager.getSubscribers("file:///a"))
+
+	// Subscriptions are dropped when the session terminates
+	subscribe("file:///a")
+	handler := newMCPHandler(withResourceManager(manager), withLifecycleManager(newLifecycleManager(Implementation{})))
+	handler.onSessionTerminated(session.GetID())
+	assert.Empty(t, manager.getSubscribers("file:///a"))
+}
+
+func TestServer_NotifyResourceUpdated(t *testing.T) {
+	server := NewServer("Test-Server", "1.0.0", WithServerPath("/mcp"))
+	server.RegisterResource(&Resource{URI: "file:///a", Name: "a"},
+		func(ctx context.Context, req *ReadResourceRequest) (ResourceContents, error) {
+			return TextResourceContents{URI: req.Params.URI, Text: "a"}, nil
+		})
+
+	httpServer := httptest.NewServer(server.HTTPHandler())
+	defer httpServer.Close()
+
+	client, err := NewClient(httpServer.URL+"/mcp", Implementation{Name: "Test-Client", Version: "1.0.0"},
+		WithClientGetSSEEnabled(true))
+	require.NoError(t, err)
+	defer client.Close()
+
+	updates := make(chan string, 10)
+	client.RegisterNotificationHandler(MethodNotificationsResourcesUpdated, func(n *JSONRPCNotification) error {
+		uri, _ := n.Params.AdditionalFields["uri"].(string)
+		updates <- uri
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	initResult, err := client.Initialize(ctx, &InitializeRequest{})
+	require.NoError(t, err)
+	require.NotNil(t, initResult.Capabilities.Resources)
+	assert.True(t, initResult.Capabilities.Resources.Subscribe)
+
+	// Nobody is notified before subscribing
+	require.NoError(t, server.NotifyResourceUpdated("file:///a"))
+
+	require.NoError(t, client.SubscribeResource(ctx, "file:///a"))
+	assert.Error(t, client.SubscribeResource(ctx, "file:///missing"))
+
+	// Sending fails until the GET SSE connection is established
+	require.Eventually(t, func() bool {
+		return server.NotifyResourceUpdated("file:///a") == nil
+	}, 5*time.Second, 50*time.Millisecond)
+	select {
+	case uri := <-updates:
+		assert.Equal(t, "file:///a", uri)
+	case <-ctx.Done():
+		t.Fatal("resource update not received")
+	}
+
+	require.NoError(t, client.UnsubscribeResource(ctx, "file:///a"))
+	require.NoError(t, server.NotifyResourceUpdated("file:///a"))
+	select {
+	case uri := <-updates:
+		t.Fatalf("unexpected update of %s after unsubscribing", uri)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
@@ -8,9 +8,13 @@ package mcp
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"trpc.group/trpc-go/trpc-mcp-go/internal/errors"
 )
 
@@ -38,17 +42,30 @@ type toolManager struct {
 	// Track insertion order of tools
 	toolsOrder []string
 
+	// Sequence number of the last registered tool
+	lastSeq uint64
+
+	// Maximum number of tools per tools/list page, zero disables pagination
+	pageSize int
+
+	// Notifier of tools/list changes
+	listChangedNotifier *listChangedNotifier
+
 	// Tool list filter function.
 	toolListFilter ToolListFilter
 
 	// Method name modifier for external customization.
 	methodNameModifier MethodNameModifier
+
+	// Whether call arguments are validated against the input schema of tools
+	inputValidation bool
 }
 
 // newToolManager creates a tool manager
 func newToolManager() *toolManager {
 	return &toolManager{
-		tools: make(map[string]*registeredTool),
+		tools:           make(map[string]*registeredTool),
+		inputValidation: true,
 	}
 }
 
@@ -70,6 +87,24 @@ func (m *toolManager) withMethodNameModifier(modifier MethodNameModifier) *toolM
 	return m
 }
 
+// withInputValidation sets whether call arguments are validated against the input schema of tools.
+func (m *toolManager) withInputValidation(enabled bool) *toolManager {
+	m.inputValidation = enabled
+	return m
+}
+
+// withListChangedNotifier sets the notifier of tools/list changes.
+func (m *toolManager) withListChangedNotifier(notifier *listChangedNotifier) *toolManager {
+	m.listChangedNotifier = notifier
+	return m
+}
+
+// withPageSize sets the maximum number of tools per tools/list page.
+func (m *toolManager) withPageSize(pageSize int) *toolManager {
+	m.pageSize = pageSize
+	return m
+}
+
 // registerTool registers a tool
 func (m *toolManager) registerTool(tool *Tool, handler toolHandler) {
 	m.mu.Lock()
@@ -79,15 +114,53 @@ func (m *toolManager) registerTool(tool *Tool, handler toolHandler) {
 		return
 	}
 
-	if _, exists := m.tools[tool.Name]; !exists {
+	existing, exists := m.tools[tool.Name]
+	var seq uint64
+	if exists {
+		// Replacing a tool keeps its position
+		seq = existing.seq
+	} else {
 		// Only add to order slice if it's a new tool
 		m.toolsOrder = append(m.toolsOrder, tool.Name)
+		m.lastSeq++
+		seq = m.lastSeq
 	}
 
 	m.tools[tool.Name] = &registeredTool{
 		Tool:    tool,
 		Handler: handler,
+		seq:     seq,
+	}
+	m.listChangedNotifier.notify(MethodNotificationsToolsListChanged)
+}
+
+// replaceTools atomically replaces all registered tools, which are then listed in the given order
+func (m *toolManager) replaceTools(tools []ServerTool) error {
+	registered := make(map[string]*registeredTool, len(tools))
+	order := make([]string, 0, len(tools))
+	for _, tool := range tools {
+		if tool.Tool == nil || tool.Tool.Name == "" || tool.Handler == nil {
+			return fmt.Errorf("tool and handler cannot be nil or unnamed")
+		}
+		if _, exists := registered[tool.Tool.Name]; exists {
+			return fmt.Errorf("duplicate tool name: %s", tool.Tool.Name)
+		}
+		registered[tool.Tool.Name] = &registeredTool{Tool: tool.Tool, Handler: tool.Handler}
+		order = append(order, tool.Tool.Name)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Assign new sequence numbers in the given order, so that cursors follow the new order
+	for _, name := range order {
+		m.lastSeq++
+		registered[name].seq = m.lastSeq
 	}
+	m.tools = registered
+	m.toolsOrder = order
+	m.listChangedNotifier.notify(MethodNotificationsToolsListChanged)
+	return nil
 }
 
 // getTool retrieves a tool by name
@@ -136,17 +209,20 @@ func (m *toolManager) unregisterTools(names ...string) int {
 		}
 	}
 
+	if unregisteredCount > 0 {
+		m.listChangedNotifier.notify(MethodNotificationsToolsListChanged)
+	}
 	return unregisteredCount
 }
 
-// getTools gets all registered tools
+// getTools gets all registered tools in registration order
 func (m *toolManager) getTools(protocolVersion string) []*Tool {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
 	tools := make([]*Tool, 0, len(m.tools))
-	for _, registeredTool := range m.tools {
-		if registeredTool != nil && registeredTool.Tool != nil {
+	for _, name := range m.toolsOrder {
+		if registeredTool := m.tools[name]; registeredTool != nil && registeredTool.Tool != nil {
 			tools = append(tools, registeredTool.Tool)
 		}
 	}
@@ -154,6 +230,45 @@ func (m *toolManager) getTools(protocolVersion string) []*Tool {
 	return tools
 }
 
+// orderToolsBySeq orders tools by registration for pagination, returning them with their
+// registration sequence numbers. Tools which are not registered and duplicates, which may be
+// returned by the tool list filter, are dropped since they cannot be located by cursors.
+func (m *toolManager) orderToolsBySeq(tools []*Tool) ([]*Tool, []uint64) {
+	m.mu.RLock()
+	seqOf := make(map[*Tool]uint64, len(tools))
+	for _, tool := range tools {
+		if tool == nil {
+			continue
+		}
+		if registeredTool, ok := m.tools[tool.Name]; ok {
+			seqOf[tool] = registeredTool.seq
+		}
+	}
+	m.mu.RUnlock()
+
+	ordered := make([]*Tool, 0, len(seqOf))
+	for _, tool := range tools {
+		if _, ok := seqOf[tool]; ok {
+			ordered = append(ordered, tool)
+		}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return seqOf[ordered[i]] < seqOf[ordered[j]]
+	})
+
+	seqs := make([]uint64, 0, len(ordered))
+	deduplicated := ordered[:0]
+	for _, tool := range ordered {
+		seq := seqOf[tool]
+		if len(seqs) > 0 && seqs[len(seqs)-1] == seq {
+			continue
+		}
+		seqs = append(seqs, seq)
+		deduplicated = append(deduplicated, tool)
+	}
+	return deduplicated, seqs
+}
+
 // handleListTools handles tools/list requests
 func (m *toolManager) handleListTools(
 	ctx context.Context,
@@ -168,6 +283,20 @@ func (m *toolManager) handleListTools(
 		toolPtrs = m.toolListFilter(ctx, toolPtrs)
 	}
 
+	// Select the requested page, the filtered tools being ordered by registration for cursors
+	cursor := getCursorParam(req)
+	var nextCursor Cursor
+	if m.pageSize > 0 || cursor != "" {
+		var seqs []uint64
+		toolPtrs, seqs = m.orderToolsBySeq(toolPtrs)
+		start, end, next, err := paginate(seqs, cursor, m.pageSize)
+		if err != nil {
+			return newJSONRPCErrorResponse(req.ID, ErrCodeInvalidParams, err.Error(), nil), nil
+		}
+		toolPtrs = toolPtrs[start:end]
+		nextCursor = next
+	}
+
 	// Convert []*mcp.Tool to []mcp.Tool
 	tools := make([]Tool, len(toolPtrs))
 	for i, toolPtr := range toolPtrs {
@@ -180,6 +309,7 @@ func (m *toolManager) handleListTools(
 	result := ListToolsResult{
 		Tools: tools,
 	}
+	result.NextCursor = nextCursor
 
 	return result, nil
 }
@@ -240,17 +370,36 @@ func (m *toolManager) handleCallTool(
 		params.Arguments = argsMap
 	}
 
-	toolReq.Params = params
+	// Validate arguments against the declared input schema
+	if m.inputValidation && !registeredTool.Tool.skipInputValidation {
+		if argErrors := validateToolArguments(registeredTool.Tool, params.Arguments); len(argErrors) > 0 {
+			errMsg := fmt.Sprintf("%v: invalid arguments (tool: %s): %s",
+				errors.ErrInvalidParams, toolName, formatToolArgumentErrors(argErrors))
+			return newJSONRPCErrorResponse(req.ID, ErrCodeInvalidParams, errMsg, argErrors), nil
+		}
+	}
 
 	// Progress notification token (if any)
 	if meta, ok := paramsMap["_meta"].(map[string]interface{}); ok {
-		if progressToken, exists := meta["progressToken"]; exists {
-			// Note: The current version of CallToolRequest doesn't fully implement Meta field
-			// Future implementation should add toolReq.Meta = ... code
-			_ = progressToken // Ignore progress token for now
+		if progressToken, exists := meta["progressToken"]; exists && progressToken != nil {
+			params.Meta = &struct {
+				ProgressToken ProgressToken `json:"progressToken,omitempty"`
+			}{
+				ProgressToken: progressToken,
+			}
+
+			// Tie progress notifications sent by the tool to the caller's token
+			if sender, ok := GetNotificationSender(ctx); ok {
+				ctx = withNotificationSender(ctx, &progressNotificationSender{
+					notificationSender: sender,
+					progressToken:      progressToken,
+				})
+			}
 		}
 	}
 
+	toolReq.Params = params
+
 	// Before calling the tool, inject server instance into context if server provider exists
 	if m.serverProvider != nil {
 		ctx = m.serverProvider.withContext(ctx)
@@ -264,9 +413,95 @@ func (m *toolManager) handleCallTool(
 	// Execute tool
 	result, err := registeredTool.Handler(ctx, toolReq)
 	if err != nil {
+		if isInvalidToolArguments(err) {
+			return newJSONRPCErrorResponse(req.ID, ErrCodeInvalidParams, err.Error(), nil), nil
+		}
 		errMsg := fmt.Sprintf("tool execution failed (tool: %s): %v", registeredTool.Tool.Name, err)
 		return newJSONRPCErrorResponse(req.ID, ErrCodeInternal, errMsg, nil), nil
 	}
 
+	// Validate structured output against the declared output schema
+	if err := validateStructuredContent(registeredTool.Tool, result); err != nil {
+		errMsg := fmt.Sprintf("invalid tool output (tool: %s): %v", registeredTool.Tool.Name, err)
+		return newJSONRPCErrorResponse(req.ID, ErrCodeInternal, errMsg, nil), nil
+	}
+
 	return result, nil
 }
+
+// validateToolArguments checks the arguments of a tool call against the tool's input schema
+// and returns the mismatching arguments sorted by path.
+func validateToolArguments(tool *Tool, arguments map[string]interface{}) []ToolArgumentError {
+	if tool.InputSchema == nil {
+		return nil
+	}
+	if arguments == nil {
+		arguments = map[string]interface{}{}
+	}
+
+	err := tool.InputSchema.VisitJSON(arguments, openapi3.MultiErrors())
+	if err == nil {
+		return nil
+	}
+	var schemaErrors []error
+	if multiErr, ok := err.(openapi3.MultiError); ok {
+		schemaErrors = multiErr
+	} else {
+		schemaErrors = []error{err}
+	}
+
+	argErrors := make([]ToolArgumentError, 0, len(schemaErrors))
+	for _, schemaErr := range schemaErrors {
+		if se, ok := schemaErr.(*openapi3.SchemaError); ok {
+			argErrors = append(argErrors, ToolArgumentError{
+				Field:   strings.Join(se.JSONPointer(), "."),
+				Message: se.Reason,
+			})
+			continue
+		}
+		argErrors = append(argErrors, ToolArgumentError{Message: schemaErr.Error()})
+	}
+	sort.SliceStable(argErrors, func(i, j int) bool {
+		return argErrors[i].Field < argErrors[j].Field
+	})
+	return argErrors
+}
+
+// formatToolArgumentErrors formats argument errors for the message of an error response
+func formatToolArgumentErrors(argErrors []ToolArgumentError) string {
+	messages := make([]string, len(argErrors))
+	for i, argErr := range argErrors {
+		if argErr.Field == "" {
+			messages[i] = argErr.Message
+			continue
+		}
+		messages[i] = fmt.Sprintf("%s: %s", argErr.Field, argErr.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// validateStructuredContent checks the structured content of a tool result against the tool's output schema.
+// Error results are not validated, since they carry an error message instead of output.
+func validateStructuredContent(tool *Tool, result *CallToolResult) error {
+	if tool == nil || tool.OutputSchema == nil || result == nil || result.IsError {
+		return nil
+	}
+	if result.StructuredContent == nil {
+		return fmt.Errorf("structured content is required by the output schema")
+	}
+
+	// Normalize the content to generic JSON values before validation
+	data, err := json.Marshal(result.StructuredContent)
+	if err != nil {
+		return fmt.Errorf("failed to marshal structured content: %w", err)
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("failed to unmarshal structured content: %w", err)
+	}
+
+	if err := tool.OutputSchema.VisitJSON(value); err != nil {
+		return fmt.Errorf("structured content does not match output schema: %w", err)
+	}
+	return nil
+}
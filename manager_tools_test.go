@@ -8,6 +8,7 @@ package mcp
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sync"
 	"testing"
@@ -610,3 +611,135 @@ func TestToolManager_MethodNameModifier(t *testing.T) {
 	assert.NoError(t, err2)
 	assert.NotNil(t, result2)
 }
+
+func TestToolManager_StructuredOutput(t *testing.T) {
+	manager := newToolManager()
+
+	type weather struct {
+		City        string  `json:"city"`
+		Temperature float64 `json:"temperature"`
+	}
+	outputSchema := &openapi3.Schema{
+		Type: &openapi3.Types{openapi3.TypeObject},
+		Properties: openapi3.Schemas{
+			"city":        openapi3.NewSchemaRef("", openapi3.NewStringSchema()),
+			"temperature": openapi3.NewSchemaRef("", openapi3.NewFloat64Schema()),
+		},
+		Required: []string{"city", "temperature"},
+	}
+
+	output := interface{}(weather{City: "Shenzhen", Temperature: 28.5})
+	manager.registerTool(NewTool("weather", WithOutputSchema(outputSchema)),
+		func(ctx context.Context, req *CallToolRequest) (*CallToolResult, error) {
+			return NewStructuredResult(output), nil
+		})
+
+	ctx := context.Background()
+	req := newJSONRPCRequest("call-1", MethodToolsCall, map[string]interface{}{"name": "weather"})
+
+	// Valid output is returned with structured and text content
+	result, err := manager.handleCallTool(ctx, req, nil)
+	require.NoError(t, err)
+	callResult, ok := result.(*CallToolResult)
+	require.True(t, ok, "Expected *CallToolResult but got %T", result)
+	assert.JSONEq(t, `{"city":"Shenzhen","temperature":28.5}`, callResult.Content[0].(TextContent).Text)
+
+	// The client decodes structured content into a Go struct
+	data, err := json.Marshal(callResult)
+	require.NoError(t, err)
+	raw := json.RawMessage(data)
+	parsed, err := parseCallToolResult(&raw)
+	require.NoError(t, err)
+	var decoded weather
+	require.NoError(t, parsed.UnmarshalStructuredContent(&decoded))
+	assert.Equal(t, weather{City: "Shenzhen", Temperature: 28.5}, decoded)
+
+	// Output violating the schema is rejected
+	output = map[string]interface{}{"city": "Shenzhen"}
+	result, err = manager.handleCallTool(ctx, req, nil)
+	require.NoError(t, err)
+	errorResp, ok := result.(*JSONRPCError)
+	require.True(t, ok, "Expected *JSONRPCError but got %T", result)
+	assert.Equal(t, ErrCodeInternal, errorResp.Error.Code)
+
+	// Missing structured content is rejected
+	manager.registerTool(NewTool("weather", WithOutputSchema(outputSchema)),
+		func(ctx context.Context, req *CallToolRequest) (*CallToolResult, error) {
+			return NewTextResult("sunny"), nil
+		})
+	result, err = manager.handleCallTool(ctx, req, nil)
+	require.NoError(t, err)
+	_, ok = result.(*JSONRPCError)
+	assert.True(t, ok, "Expected *JSONRPCError but got %T", result)
+}
+
+func TestToolManager_InputValidation(t *testing.T) {
+	manager := newToolManager()
+	handler := func(ctx context.Context, req *CallToolRequest) (*CallToolResult, error) {
+		return NewTextResult("ok"), nil
+	}
+	manager.registerTool(NewTool("search",
+		WithString("query", Required()),
+		WithString("order", Enum("asc", "desc")),
+		WithArray("tags", Items(openapi3.NewStringSchema()), MinItems(1), UniqueItems(true)),
+		WithObject("filter", Required(), Properties(openapi3.Schemas{
+			"limit": openapi3.NewSchemaRef("", openapi3.NewIntegerSchema()),
+		})),
+	), handler)
+	manager.registerTool(NewTool("raw", WithString("query", Required()), WithoutInputValidation()), handler)
+
+	ctx := context.Background()
+	call := func(name string, arguments map[string]interface{}) JSONRPCMessage {
+		req := newJSONRPCRequest("call-1", MethodToolsCall, map[string]interface{}{
+			"name":      name,
+			"arguments": arguments,
+		})
+		result, err := manager.handleCallTool(ctx, req, nil)
+		require.NoError(t, err)
+		return result
+	}
+
+	// Valid arguments reach the handler
+	result := call("search", map[string]interface{}{
+		"query":  "mcp",
+		"order":  "asc",
+		"tags":   []interface{}{"a", "b"},
+		"filter": map[string]interface{}{"limit": float64(10)},
+	})
+	_, ok := result.(*CallToolResult)
+	require.True(t, ok, "Expected *CallToolResult but got %T", result)
+
+	// Every mismatching argument is reported
+	result = call("search", map[string]interface{}{
+		"order":  "random",
+		"tags":   []interface{}{"a", "a"},
+		"filter": map[string]interface{}{"limit": "ten"},
+	})
+	errorResp, ok := result.(*JSONRPCError)
+	require.True(t, ok, "Expected *JSONRPCError but got %T", result)
+	assert.Equal(t, ErrCodeInvalidParams, errorResp.Error.Code)
+	assert.Contains(t, errorResp.Error.Message, "tool: search")
+	argErrors, ok := errorResp.Error.Data.([]ToolArgumentError)
+	require.True(t, ok, "Expected []ToolArgumentError but got %T", errorResp.Error.Data)
+	fields := make([]string, len(argErrors))
+	for i, argErr := range argErrors {
+		fields[i] = argErr.Field
+		assert.NotEmpty(t, argErr.Message)
+	}
+	assert.Equal(t, []string{"filter.limit", "order", "query", "tags"}, fields)
+
+	result = call("search", map[string]interface{}{"query": "mcp", "tags": []interface{}{}, "filter": map[string]interface{}{}})
+	errorResp, ok = result.(*JSONRPCError)
+	require.True(t, ok, "Expected *JSONRPCError but got %T", result)
+	assert.Equal(t, []ToolArgumentError{{Field: "tags", Message: "minimum number of items is 1"}}, errorResp.Error.Data)
+
+	// Validation can be disabled per tool and per manager
+	result = call("raw", nil)
+	_, ok = result.(*CallToolResult)
+	assert.True(t, ok, "Expected *CallToolResult but got %T", result)
+
+	manager.withInputValidation(false)
+	result = call("search", map[string]interface{}{"order": "random"})
+	_, ok = result.(*CallToolResult)
+	assert.True(t, ok, "Expected *CallToolResult but got %T", result)
+}
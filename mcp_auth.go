@@ -0,0 +1,488 @@
+// Tencent is pleased to support the open source community by making trpc-mcp-go available.
+//
+// Copyright (C) 2025 Tencent.  All rights reserved.
+//
+// trpc-mcp-go is licensed under the Apache License Version 2.0.
+
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrInvalidToken is returned by token verifiers when an access token is invalid, expired or not
+// issued for the server
+var ErrInvalidToken = errors.New("invalid access token")
+
+const (
+	// protectedResourceMetadataPath is the well-known path of the protected resource metadata (RFC 9728)
+	protectedResourceMetadataPath = "/.well-known/oauth-protected-resource"
+
+	// bearerPrefix is the authentication scheme prefix of bearer tokens in the Authorization header
+	bearerPrefix = "bearer "
+
+	// sessionPrincipalKey is the session data key of the principal a session was created for
+	sessionPrincipalKey = "mcp.auth.principal"
+)
+
+// AuthInfo contains the information of a verified access token
+type AuthInfo struct {
+	// Access token
+	Token string
+
+	// Subject of the token, usually the user
+	Subject string
+
+	// Client the token was issued to
+	ClientID string
+
+	// Audiences of the token, which must contain the resource URL of the server
+	Audience []string
+
+	// Scopes granted by the token
+	Scopes []string
+
+	// Expiration time of the token, zero if it does not expire
+	ExpiresAt time.Time
+
+	// All claims of the token
+	Claims map[string]interface{}
+}
+
+// HasScopes reports whether the token grants all the scopes
+func (a *AuthInfo) HasScopes(scopes ...string) bool {
+	return len(a.missingScopes(scopes)) == 0
+}
+
+// principal returns the identity sessions are bound to, the subject of the token or its client without subject
+func (a *AuthInfo) principal() string {
+	if a.Subject != "" {
+		return a.Subject
+	}
+	return a.ClientID
+}
+
+// hasAudience reports whether the token was issued for one of the audiences
+func (a *AuthInfo) hasAudience(audiences []string) bool {
+	for _, aud := range a.Audience {
+		for _, audience := range audiences {
+			if strings.TrimSuffix(aud, "/") == strings.TrimSuffix(audience, "/") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// missingScopes returns the scopes not granted by the token
+func (a *AuthInfo) missingScopes(scopes []string) []string {
+	var missing []string
+	for _, scope := range scopes {
+		granted := false
+		for _, s := range a.Scopes {
+			if s == scope {
+				granted = true
+				break
+			}
+		}
+		if !granted {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
+
+// TokenVerifier verifies the bearer tokens of requests, see NewJWTVerifier and NewIntrospectionVerifier.
+// VerifyToken returns an error, usually wrapping ErrInvalidToken, if the token must be rejected, and
+// otherwise the information of the token including its audiences.
+type TokenVerifier interface {
+	VerifyToken(ctx context.Context, token string) (*AuthInfo, error)
+}
+
+// ProtectedResourceMetadata is the OAuth 2.0 protected resource metadata of the server (RFC 9728)
+type ProtectedResourceMetadata struct {
+	Resource               string   `json:"resource"`
+	AuthorizationServers   []string `json:"authorization_servers,omitempty"`
+	ScopesSupported        []string `json:"scopes_supported,omitempty"`
+	BearerMethodsSupported []string `json:"bearer_methods_supported,omitempty"`
+	ResourceName           string   `json:"resource_name,omitempty"`
+}
+
+// authInfoContextKey is the context key of the verified access token
+type authInfoContextKey struct{}
+
+// setAuthInfoToContext adds the information of a verified access token to the context
+func setAuthInfoToContext(ctx context.Context, info *AuthInfo) context.Context {
+	return context.WithValue(ctx, authInfoContextKey{}, info)
+}
+
+// GetAuthInfoFromContext gets the information of the verified access token of the request from the context
+func GetAuthInfoFromContext(ctx context.Context) (*AuthInfo, bool) {
+	info, ok := ctx.Value(authInfoContextKey{}).(*AuthInfo)
+	return info, ok
+}
+
+// bindSession binds a new session to the principal of the verified access token of the context
+func bindSession(ctx context.Context, session Session) {
+	if info, ok := GetAuthInfoFromContext(ctx); ok {
+		session.SetData(sessionPrincipalKey, info.principal())
+	}
+}
+
+// sessionAllowed reports whether a session may be used with the verified access token of the
+// context, sessions created with a token being bound to its principal
+func sessionAllowed(ctx context.Context, session Session) bool {
+	principal, bound := session.GetData(sessionPrincipalKey)
+	if !bound {
+		return true
+	}
+	info, ok := GetAuthInfoFromContext(ctx)
+	return ok && info.principal() == principal
+}
+
+// authorizationConfig stores the authorization configuration of a server
+type authorizationConfig struct {
+	verifier             TokenVerifier
+	resourceURL          string
+	audiences            []string
+	authorizationServers []string
+	requiredScopes       []string
+	methodScopes         map[string][]string
+	toolScopes           map[string][]string
+}
+
+// AuthorizationOption configures the authorization of a server
+type AuthorizationOption func(*authorizationConfig)
+
+// WithAuthResourceURL sets the canonical URL of the MCP endpoint, which is the resource of the
+// metadata document and the audience of access tokens. Without it, the resource of the metadata
+// document is derived from the Host header of requests and the server path, and all access tokens
+// are rejected unless WithAuthAudiences is set.
+func WithAuthResourceURL(resourceURL string) AuthorizationOption {
+	return func(c *authorizationConfig) {
+		c.resourceURL = strings.TrimSuffix(resourceURL, "/")
+	}
+}
+
+// WithAuthAudiences sets the audiences accepted in access tokens instead of the resource URL
+func WithAuthAudiences(audiences ...string) AuthorizationOption {
+	return func(c *authorizationConfig) {
+		c.audiences = append(c.audiences, audiences...)
+	}
+}
+
+// WithAuthServers sets the issuer URLs of the authorization servers listed in the metadata document
+func WithAuthServers(issuers ...string) AuthorizationOption {
+	return func(c *authorizationConfig) {
+		c.authorizationServers = append(c.authorizationServers, issuers...)
+	}
+}
+
+// WithAuthRequiredScopes sets the scopes every request must be granted
+func WithAuthRequiredScopes(scopes ...string) AuthorizationOption {
+	return func(c *authorizationConfig) {
+		c.requiredScopes = append(c.requiredScopes, scopes...)
+	}
+}
+
+// WithAuthMethodScopes sets the scopes required to call a method, e.g. MethodResourcesRead
+func WithAuthMethodScopes(method string, scopes ...string) AuthorizationOption {
+	return func(c *authorizationConfig) {
+		if c.methodScopes == nil {
+			c.methodScopes = make(map[string][]string)
+		}
+		c.methodScopes[method] = append(c.methodScopes[method], scopes...)
+	}
+}
+
+// WithAuthToolScopes sets the scopes required to call a tool
+func WithAuthToolScopes(tool string, scopes ...string) AuthorizationOption {
+	return func(c *authorizationConfig) {
+		if c.toolScopes == nil {
+			c.toolScopes = make(map[string][]string)
+		}
+		c.toolScopes[tool] = append(c.toolScopes[tool], scopes...)
+	}
+}
+
+// acceptedAudiences returns the audiences accepted in access tokens, none if the server has no resource URL
+func (c *authorizationConfig) acceptedAudiences() []string {
+	if len(c.audiences) > 0 {
+		return c.audiences
+	}
+	if c.resourceURL != "" {
+		return []string{c.resourceURL}
+	}
+	return nil
+}
+
+// scopesSupported returns all the scopes used by the configuration, sorted
+func (c *authorizationConfig) scopesSupported() []string {
+	set := make(map[string]bool)
+	add := func(scopes []string) {
+		for _, scope := range scopes {
+			set[scope] = true
+		}
+	}
+	add(c.requiredScopes)
+	for _, scopes := range c.methodScopes {
+		add(scopes)
+	}
+	for _, scopes := range c.toolScopes {
+		add(scopes)
+	}
+
+	scopes := make([]string, 0, len(set))
+	for scope := range set {
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+	return scopes
+}
+
+// requestScopes returns the scopes required by the methods and tools of the requests of a message or batch
+func (c *authorizationConfig) requestScopes(body []byte) []string {
+	messages := []json.RawMessage{body}
+	if isBatchMessage(body) {
+		// Malformed messages are rejected by the MCP handler
+		if err := json.Unmarshal(body, &messages); err != nil {
+			return nil
+		}
+	}
+
+	var required []string
+	for _, message := range messages {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Params interface{}     `json:"params"`
+		}
+		if err := json.Unmarshal(message, &req); err != nil || req.ID == nil {
+			continue
+		}
+		required = append(required, c.methodScopes[req.Method]...)
+		if req.Method != MethodToolsCall {
+			continue
+		}
+		if params, ok := req.Params.(map[string]interface{}); ok {
+			if name, ok := params["name"].(string); ok {
+				required = append(required, c.toolScopes[name]...)
+			}
+		}
+	}
+	return required
+}
+
+// authorizationHandler serves the protected resource metadata and authorizes the requests of
+// the MCP endpoint with their bearer tokens
+type authorizationHandler struct {
+	config       *authorizationConfig
+	next         http.Handler
+	serverPath   string
+	resourceName string
+	logger       Logger
+}
+
+// newAuthorizationHandler creates an authorization handler in front of next
+func newAuthorizationHandler(
+	config *authorizationConfig,
+	next http.Handler,
+	serverPath string,
+	resourceName string,
+	logger Logger,
+) *authorizationHandler {
+	if logger == nil {
+		logger = GetDefaultLogger()
+	}
+	if len(config.acceptedAudiences()) == 0 {
+		logger.Errorf("No resource URL or audience configured for authorization, all access tokens will be rejected")
+	}
+	return &authorizationHandler{
+		config:       config,
+		next:         next,
+		serverPath:   serverPath,
+		resourceName: resourceName,
+		logger:       logger,
+	}
+}
+
+// ServeHTTP implements http.Handler
+func (h *authorizationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == protectedResourceMetadataPath || r.URL.Path == h.metadataPath() {
+		h.serveMetadata(w, r)
+		return
+	}
+
+	token, ok := bearerToken(r)
+	if !ok {
+		h.unauthorized(w, r, "")
+		return
+	}
+	info, err := h.config.verifier.VerifyToken(r.Context(), token)
+	if err == nil {
+		err = checkAuthInfo(info)
+	}
+	if err == nil {
+		err = checkAudience(info, h.config.acceptedAudiences())
+	}
+	if err != nil {
+		h.logger.Infof("Rejected access token: %v", err)
+		h.unauthorized(w, r, "invalid_token")
+		return
+	}
+
+	// Check the scopes of the server and of the methods and tools of the requests
+	required := h.config.requiredScopes
+	if r.Method == http.MethodPost {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "Failed to read request body", http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		required = append(append([]string(nil), required...), h.config.requestScopes(body)...)
+	}
+	if missing := info.missingScopes(required); len(missing) > 0 {
+		h.insufficientScope(w, r, required)
+		return
+	}
+
+	verified := *info
+	verified.Token = token
+	h.next.ServeHTTP(w, r.WithContext(setAuthInfoToContext(r.Context(), &verified)))
+}
+
+// checkAuthInfo rejects tokens a verifier accepted without information or after their expiration
+func checkAuthInfo(info *AuthInfo) error {
+	if info == nil {
+		return fmt.Errorf("%w: no token information", ErrInvalidToken)
+	}
+	if !info.ExpiresAt.IsZero() && time.Now().After(info.ExpiresAt) {
+		return fmt.Errorf("%w: token expired", ErrInvalidToken)
+	}
+	return nil
+}
+
+// checkAudience rejects tokens not issued for one of the audiences, and all tokens without audiences,
+// which are never derived from the headers of requests
+func checkAudience(info *AuthInfo, audiences []string) error {
+	if len(audiences) == 0 {
+		return fmt.Errorf("%w: no resource URL or audience configured", ErrInvalidToken)
+	}
+	if !info.hasAudience(audiences) {
+		return fmt.Errorf("%w: token not issued for %s", ErrInvalidToken, strings.Join(audiences, ", "))
+	}
+	return nil
+}
+
+// unauthorized rejects a request without a valid access token, pointing the client at the metadata document
+func (h *authorizationHandler) unauthorized(w http.ResponseWriter, r *http.Request, errorCode string) {
+	challenge := fmt.Sprintf(`Bearer resource_metadata="%s"`, h.metadataURL(r))
+	if errorCode != "" {
+		challenge = fmt.Sprintf(`Bearer error="%s", resource_metadata="%s"`, errorCode, h.metadataURL(r))
+	}
+	w.Header().Set("WWW-Authenticate", challenge)
+	http.Error(w, "Unauthorized", http.StatusUnauthorized)
+}
+
+// insufficientScope rejects a request whose access token lacks some of the scopes, listing all the scopes it requires
+func (h *authorizationHandler) insufficientScope(w http.ResponseWriter, r *http.Request, scopes []string) {
+	var unique []string
+	seen := make(map[string]bool)
+	for _, scope := range scopes {
+		if !seen[scope] {
+			seen[scope] = true
+			unique = append(unique, scope)
+		}
+	}
+	w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer error="insufficient_scope", scope="%s", resource_metadata="%s"`,
+		strings.Join(unique, " "), h.metadataURL(r)))
+	http.Error(w, "Insufficient scope", http.StatusForbidden)
+}
+
+// serveMetadata writes the protected resource metadata document
+func (h *authorizationHandler) serveMetadata(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	metadata := ProtectedResourceMetadata{
+		Resource:               h.resourceURL(r),
+		AuthorizationServers:   h.config.authorizationServers,
+		ScopesSupported:        h.config.scopesSupported(),
+		BearerMethodsSupported: []string{"header"},
+		ResourceName:           h.resourceName,
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(metadata); err != nil {
+		h.logger.Infof("Failed to write protected resource metadata: %v", err)
+	}
+}
+
+// resourceURL returns the URL of the MCP endpoint
+func (h *authorizationHandler) resourceURL(r *http.Request) string {
+	if h.config.resourceURL != "" {
+		return h.config.resourceURL
+	}
+	return requestBaseURL(r) + h.serverPath
+}
+
+// metadataPath returns the path of the metadata document of the MCP endpoint
+func (h *authorizationHandler) metadataPath() string {
+	return protectedResourceMetadataPath + resourcePath(h.config.resourceURL, h.serverPath)
+}
+
+// metadataURL returns the URL of the metadata document, the well-known path being inserted
+// between the host and the path of the resource URL
+func (h *authorizationHandler) metadataURL(r *http.Request) string {
+	if h.config.resourceURL == "" {
+		return requestBaseURL(r) + h.metadataPath()
+	}
+	resourceURL := h.config.resourceURL
+	path := resourcePath(resourceURL, "")
+	return strings.TrimSuffix(resourceURL, path) + protectedResourceMetadataPath + path
+}
+
+// resourcePath returns the path of the resource URL, or defaultPath if no resource URL is set
+func resourcePath(resourceURL, defaultPath string) string {
+	if resourceURL == "" {
+		return defaultPath
+	}
+	rest := resourceURL
+	if i := strings.Index(rest, "://"); i >= 0 {
+		rest = rest[i+len("://"):]
+	}
+	if i := strings.Index(rest, "/"); i >= 0 {
+		return rest[i:]
+	}
+	return ""
+}
+
+// requestBaseURL returns the scheme and host of a request
+func requestBaseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil || strings.EqualFold(r.Header.Get("X-Forwarded-Proto"), "https") {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
+// bearerToken returns the bearer token of the Authorization header of a request
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	if len(header) <= len(bearerPrefix) || !strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+		return "", false
+	}
+	token := strings.TrimSpace(header[len(bearerPrefix):])
+	return token, token != ""
+}
@@ -0,0 +1,391 @@
+// Tencent is pleased to support the open source community by making trpc-mcp-go available.
+//
+// Copyright (C) 2025 Tencent.  All rights reserved.
+//
+// trpc-mcp-go is licensed under the Apache License Version 2.0.
+
+package mcp
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signTestJWT signs the claims with the key, an *ecdsa.PrivateKey for ES256 or an *rsa.PrivateKey for RS256
+func signTestJWT(t *testing.T, key crypto.Signer, kid string, claims map[string]interface{}) string {
+	alg := "RS256"
+	if _, ok := key.(*ecdsa.PrivateKey); ok {
+		alg = "ES256"
+	}
+	header, err := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "at+jwt"})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	digest := sha256.Sum256([]byte(signed))
+	var signature []byte
+	switch key := key.(type) {
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+		require.NoError(t, err)
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	case *rsa.PrivateKey:
+		signature, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// writeTestJWKS writes the public keys of an EC key with kid "ec" and an RSA key with kid "rsa" to a JWKS file
+func writeTestJWKS(t *testing.T, ecKey *ecdsa.PrivateKey, rsaKey *rsa.PrivateKey) string {
+	encode := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+	jwks := map[string]interface{}{"keys": []map[string]string{
+		{
+			"kty": "EC", "kid": "ec", "crv": "P-256", "use": "sig",
+			"x": encode(ecKey.X.FillBytes(make([]byte, 32))), "y": encode(ecKey.Y.FillBytes(make([]byte, 32))),
+		},
+		{
+			"kty": "RSA", "kid": "rsa", "alg": "RS256",
+			"n": encode(rsaKey.N.Bytes()), "e": encode(big.NewInt(int64(rsaKey.E)).Bytes()),
+		},
+		{"kty": "RSA", "kid": "enc", "use": "enc"},
+	}}
+	data, err := json.Marshal(jwks)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, data, 0600))
+	return path
+}
+
+func TestJWTVerifier(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	verifier, err := NewJWTVerifier(writeTestJWKS(t, ecKey, rsaKey),
+		WithJWTIssuer("https://auth.example.com"), WithJWTAudience("https://mcp.example.com/mcp"))
+	require.NoError(t, err)
+	claims := func(overrides map[string]interface{}) map[string]interface{} {
+		c := map[string]interface{}{
+			"iss":       "https://auth.example.com",
+			"aud":       []string{"https://mcp.example.com/mcp"},
+			"sub":       "alice",
+			"client_id": "app",
+			"scope":     "tools:read tools:write",
+			"exp":       time.Now().Add(time.Hour).Unix(),
+		}
+		for k, v := range overrides {
+			c[k] = v
+		}
+		return c
+	}
+	ctx := context.Background()
+
+	// Tokens signed with any key of the set are accepted
+	for _, token := range []string{
+		signTestJWT(t, ecKey, "ec", claims(nil)),
+		signTestJWT(t, rsaKey, "rsa", claims(nil)),
+	} {
+		info, err := verifier.VerifyToken(ctx, token)
+		require.NoError(t, err)
+		assert.Equal(t, "alice", info.Subject)
+		assert.Equal(t, "app", info.ClientID)
+		assert.Equal(t, []string{"https://mcp.example.com/mcp"}, info.Audience)
+		assert.Equal(t, []string{"tools:read", "tools:write"}, info.Scopes)
+		assert.True(t, info.HasScopes("tools:write"))
+		assert.False(t, info.HasScopes("admin"))
+	}
+
+	// Scopes can be given as an scp array
+	info, err := verifier.VerifyToken(ctx, signTestJWT(t, ecKey, "ec", claims(map[string]interface{}{
+		"scope": nil, "scp": []string{"admin"},
+	})))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admin"}, info.Scopes)
+
+	invalidTokens := map[string]string{
+		"malformed":      "not-a-jwt",
+		"unknown key":    signTestJWT(t, otherKey, "ec", claims(nil)),
+		"wrong kid":      signTestJWT(t, ecKey, "rsa", claims(nil)),
+		"expired":        signTestJWT(t, ecKey, "ec", claims(map[string]interface{}{"exp": time.Now().Add(-time.Minute).Unix()})),
+		"no expiration":  signTestJWT(t, ecKey, "ec", claims(map[string]interface{}{"exp": nil})),
+		"not yet valid":  signTestJWT(t, ecKey, "ec", claims(map[string]interface{}{"nbf": time.Now().Add(time.Hour).Unix()})),
+		"wrong issuer":   signTestJWT(t, ecKey, "ec", claims(map[string]interface{}{"iss": "https://evil.example.com"})),
+		"wrong audience": signTestJWT(t, ecKey, "ec", claims(map[string]interface{}{"aud": "https://other.example.com"})),
+	}
+	for name, token := range invalidTokens {
+		_, err := verifier.VerifyToken(ctx, token)
+		assert.ErrorIs(t, err, ErrInvalidToken, name)
+	}
+
+	_, err = NewJWTVerifier(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestIntrospectionVerifier(t *testing.T) {
+	introspection := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientID, secret, ok := r.BasicAuth()
+		if !ok || clientID != "mcp-server" || secret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		response := map[string]interface{}{"active": false}
+		if r.PostFormValue("token") == "valid" {
+			response = map[string]interface{}{
+				"active": true, "sub": "bob", "scope": "tools:read", "aud": "https://mcp.example.com/mcp",
+				"exp": time.Now().Add(time.Hour).Unix(),
+			}
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer introspection.Close()
+
+	verifier := NewIntrospectionVerifier(introspection.URL,
+		WithIntrospectionClientCredentials("mcp-server", "secret"),
+		WithIntrospectionAudience("https://mcp.example.com/mcp"))
+	info, err := verifier.VerifyToken(context.Background(), "valid")
+	require.NoError(t, err)
+	assert.Equal(t, "bob", info.Subject)
+	assert.Equal(t, []string{"https://mcp.example.com/mcp"}, info.Audience)
+	assert.Equal(t, []string{"tools:read"}, info.Scopes)
+	assert.False(t, info.ExpiresAt.IsZero())
+
+	_, err = verifier.VerifyToken(context.Background(), "revoked")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	// Failures of the endpoint are not reported as invalid tokens
+	_, err = NewIntrospectionVerifier(introspection.URL).VerifyToken(context.Background(), "valid")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestServer_Authorization(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	verifier, err := NewJWTVerifier(writeTestJWKS(t, ecKey, rsaKey))
+	require.NoError(t, err)
+
+	server := NewServer("Test-Server", "1.0.0", WithServerPath("/mcp"), WithAuthorization(verifier,
+		WithAuthAudiences("https://mcp.example.com/mcp"),
+		WithAuthServers("https://auth.example.com"),
+		WithAuthRequiredScopes("mcp"),
+		WithAuthMethodScopes(MethodPromptsList, "prompts:read"),
+		WithAuthToolScopes("admin", "admin:write"),
+	))
+	whoami := func(ctx context.Context, req *CallToolRequest) (*CallToolResult, error) {
+		info, ok := GetAuthInfoFromContext(ctx)
+		if !ok {
+			return NewErrorResult("unauthenticated"), nil
+		}
+		return NewTextResult(info.Subject), nil
+	}
+	server.RegisterTool(NewTool("whoami"), whoami)
+	server.RegisterTool(NewTool("admin"), whoami)
+	httpServer := httptest.NewServer(server.HTTPHandler())
+	defer httpServer.Close()
+	metadataURL := httpServer.URL + "/.well-known/oauth-protected-resource/mcp"
+
+	// The metadata document is served without a token
+	resp, err := http.Get(metadataURL)
+	require.NoError(t, err)
+	var metadata ProtectedResourceMetadata
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&metadata))
+	resp.Body.Close()
+	assert.Equal(t, ProtectedResourceMetadata{
+		Resource:               httpServer.URL + "/mcp",
+		AuthorizationServers:   []string{"https://auth.example.com"},
+		ScopesSupported:        []string{"admin:write", "mcp", "prompts:read"},
+		BearerMethodsSupported: []string{"header"},
+		ResourceName:           "Test-Server",
+	}, metadata)
+
+	post := func(token, body string) *http.Response {
+		req, err := http.NewRequest(http.MethodPost, httpServer.URL+"/mcp", strings.NewReader(body))
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+	newSubjectToken := func(sub, scope, aud string) string {
+		return signTestJWT(t, ecKey, "ec", map[string]interface{}{
+			"sub": sub, "scope": scope, "aud": aud, "exp": time.Now().Add(time.Hour).Unix(),
+		})
+	}
+	newToken := func(scope string) string {
+		return newSubjectToken("alice", scope, "https://mcp.example.com/mcp")
+	}
+
+	// Requests without a valid token are rejected with a challenge pointing at the metadata document
+	resp = post("", `{}`)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, `Bearer resource_metadata="`+metadataURL+`"`, resp.Header.Get("WWW-Authenticate"))
+	resp = post("invalid", `{}`)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, `Bearer error="invalid_token", resource_metadata="`+metadataURL+`"`, resp.Header.Get("WWW-Authenticate"))
+	resp = post(newToken("tools:read"), `{}`)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("WWW-Authenticate"), `error="insufficient_scope", scope="mcp"`)
+
+	// Tokens must be issued for the configured audience, whatever the Host header of the request
+	for _, aud := range []string{"", "https://other.example.com/mcp", httpServer.URL + "/mcp"} {
+		resp = post(newSubjectToken("alice", "mcp", aud), `{}`)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "aud=%q", aud)
+	}
+	req, err := http.NewRequest(http.MethodPost, httpServer.URL+"/mcp", strings.NewReader(`{}`))
+	require.NoError(t, err)
+	req.Host = "other.example.com"
+	req.Header.Set("X-Forwarded-Proto", "https")
+	req.Header.Set("Authorization", "Bearer "+newSubjectToken("alice", "mcp", "https://other.example.com/mcp"))
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	// Requests lacking the scopes of their methods or tools are rejected with a challenge listing them
+	resp = post(newToken("mcp"), `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"admin"}}`)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Equal(t, `Bearer error="insufficient_scope", scope="mcp admin:write", resource_metadata="`+metadataURL+`"`,
+		resp.Header.Get("WWW-Authenticate"))
+	resp = post(newToken("mcp"), `[{"jsonrpc":"2.0","id":1,"method":"tools/list"},{"jsonrpc":"2.0","id":2,"method":"prompts/list"}]`)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("WWW-Authenticate"), `scope="mcp prompts:read"`)
+
+	// Handlers receive the claims of the token, and methods and tools require their scopes
+	client, err := NewClient(httpServer.URL+"/mcp", Implementation{Name: "Test-Client", Version: "1.0.0"},
+		WithHTTPHeaders(http.Header{"Authorization": {"Bearer " + newToken("mcp")}}))
+	require.NoError(t, err)
+	defer client.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	initResult, err := client.Initialize(ctx, &InitializeRequest{})
+	require.NoError(t, err)
+
+	result, err := client.CallTool(ctx, &CallToolRequest{Params: CallToolParams{Name: "whoami"}})
+	require.NoError(t, err)
+	assert.Equal(t, "alice", result.Content[0].(TextContent).Text)
+	_, err = client.CallTool(ctx, &CallToolRequest{Params: CallToolParams{Name: "admin"}})
+	assert.ErrorContains(t, err, "status code 403")
+	_, err = client.ListPrompts(ctx, &ListPromptsRequest{})
+	assert.ErrorContains(t, err, "status code 403")
+
+	// Sessions are bound to the subject of the token they were created with
+	sessionID := client.GetSessionID()
+	require.NotEmpty(t, sessionID)
+	listTools := func(token string) *http.Response {
+		req, err := http.NewRequest(http.MethodPost, httpServer.URL+"/mcp",
+			strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json, text/event-stream")
+		req.Header.Set("Mcp-Session-Id", sessionID)
+		req.Header.Set("MCP-Protocol-Version", initResult.ProtocolVersion)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+	assert.Equal(t, http.StatusOK, listTools(newToken("mcp")).StatusCode)
+	assert.Equal(t, http.StatusNotFound, listTools(newSubjectToken("mallory", "mcp", "https://mcp.example.com/mcp")).StatusCode)
+}
+
+func TestServer_AuthorizationAudiences(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	verifier, err := NewJWTVerifier(writeTestJWKS(t, ecKey, rsaKey))
+	require.NoError(t, err)
+
+	server := NewServer("Test-Server", "1.0.0", WithServerPath("/mcp"), WithAuthorization(verifier,
+		WithAuthResourceURL("https://mcp.example.com/mcp"),
+		WithAuthAudiences("api://mcp", "https://mcp.example.com/"),
+	))
+	httpServer := httptest.NewServer(server.HTTPHandler())
+	defer httpServer.Close()
+
+	for aud, status := range map[string]int{
+		"api://mcp":                   http.StatusOK,
+		"https://mcp.example.com":     http.StatusOK,
+		"https://mcp.example.com/mcp": http.StatusUnauthorized,
+	} {
+		token := signTestJWT(t, ecKey, "ec", map[string]interface{}{
+			"sub": "alice", "aud": aud, "exp": time.Now().Add(time.Hour).Unix(),
+		})
+		req, err := http.NewRequest(http.MethodPost, httpServer.URL+"/mcp", strings.NewReader(
+			`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"c","version":"1"}}}`))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json, text/event-stream")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, status, resp.StatusCode, "aud=%s", aud)
+	}
+}
+
+func TestServer_AuthorizationWithoutAudience(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	verifier, err := NewJWTVerifier(writeTestJWKS(t, ecKey, rsaKey))
+	require.NoError(t, err)
+
+	server := NewServer("Test-Server", "1.0.0", WithServerPath("/mcp"), WithAuthorization(verifier))
+	httpServer := httptest.NewServer(server.HTTPHandler())
+	defer httpServer.Close()
+
+	// Without resource URL or audiences, tokens are rejected even when issued for the Host of the request
+	for _, host := range []string{"", "other.example.com"} {
+		req, err := http.NewRequest(http.MethodPost, httpServer.URL+"/mcp", strings.NewReader(`{}`))
+		require.NoError(t, err)
+		aud := httpServer.URL + "/mcp"
+		if host != "" {
+			req.Host = host
+			aud = "http://" + host + "/mcp"
+		}
+		req.Header.Set("Authorization", "Bearer "+signTestJWT(t, ecKey, "ec", map[string]interface{}{
+			"sub": "alice", "aud": aud, "exp": time.Now().Add(time.Hour).Unix(),
+		}))
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "host=%q", host)
+	}
+
+	// The metadata document is still served
+	resp, err := http.Get(httpServer.URL + "/.well-known/oauth-protected-resource/mcp")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -0,0 +1,419 @@
+// Tencent is pleased to support the open source community by making trpc-mcp-go available.
+//
+// Copyright (C) 2025 Tencent.  All rights reserved.
+//
+// trpc-mcp-go is licensed under the Apache License Version 2.0.
+
+package mcp
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	_ "crypto/sha256" // registers the SHA-256 hash of RS256, PS256 and ES256
+	_ "crypto/sha512" // registers the SHA-384 and SHA-512 hashes
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// jsonWebKey is a public key of a JSON Web Key Set (RFC 7517)
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+
+	key crypto.PublicKey
+}
+
+// JWTVerifier verifies JWT access tokens (RFC 9068) with the public keys of a local JWKS file.
+// RS*, PS*, ES* and EdDSA signatures are supported.
+type JWTVerifier struct {
+	keys     []*jsonWebKey
+	issuer   string
+	audience string
+}
+
+// JWTVerifierOption configures a JWT verifier
+type JWTVerifierOption func(*JWTVerifier)
+
+// WithJWTIssuer requires the iss claim of tokens to be the issuer
+func WithJWTIssuer(issuer string) JWTVerifierOption {
+	return func(v *JWTVerifier) {
+		v.issuer = issuer
+	}
+}
+
+// WithJWTAudience requires the aud claim of tokens to contain the audience, usually the resource URL of the server
+func WithJWTAudience(audience string) JWTVerifierOption {
+	return func(v *JWTVerifier) {
+		v.audience = audience
+	}
+}
+
+// NewJWTVerifier creates a JWT verifier with the public keys of a JWKS file
+func NewJWTVerifier(jwksFile string, opts ...JWTVerifierOption) (*JWTVerifier, error) {
+	data, err := os.ReadFile(jwksFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+	}
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &JWTVerifier{keys: keys}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v, nil
+}
+
+// parseJWKS parses the supported public keys of a JSON Web Key Set
+func parseJWKS(data []byte) ([]*jsonWebKey, error) {
+	var set struct {
+		Keys []*jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS: %w", err)
+	}
+
+	var keys []*jsonWebKey
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %q in JWKS: %w", k.Kid, err)
+		}
+		k.key = key
+		keys = append(keys, k)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no signing keys in JWKS")
+	}
+	return keys, nil
+}
+
+// publicKey decodes the public key of a JSON Web Key
+func (k *jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBase64URLInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBase64URLInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBase64URLInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBase64URLInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+// decodeBase64URLInt decodes a big-endian unsigned integer encoded in base64url
+func decodeBase64URLInt(s string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(data) == 0 {
+		return nil, fmt.Errorf("invalid base64url integer")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+// VerifyToken implements TokenVerifier
+func (v *JWTVerifier) VerifyToken(ctx context.Context, token string) (*AuthInfo, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed JWT", ErrInvalidToken)
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed signature", ErrInvalidToken)
+	}
+
+	signed := []byte(parts[0] + "." + parts[1])
+	verified := false
+	for _, k := range v.keys {
+		if (header.Kid != "" && k.Kid != header.Kid) || (k.Alg != "" && k.Alg != header.Alg) {
+			continue
+		}
+		if verifyJWTSignature(header.Alg, k.key, signed, signature) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, fmt.Errorf("%w: signature verification failed", ErrInvalidToken)
+	}
+
+	var claims map[string]interface{}
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	now := time.Now()
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("%w: missing exp claim", ErrInvalidToken)
+	}
+	if now.After(time.Unix(int64(exp), 0)) {
+		return nil, fmt.Errorf("%w: token expired", ErrInvalidToken)
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Before(time.Unix(int64(nbf), 0)) {
+		return nil, fmt.Errorf("%w: token not yet valid", ErrInvalidToken)
+	}
+	if v.issuer != "" && claims["iss"] != v.issuer {
+		return nil, fmt.Errorf("%w: unexpected issuer %v", ErrInvalidToken, claims["iss"])
+	}
+	if v.audience != "" && !hasAudience(claims["aud"], v.audience) {
+		return nil, fmt.Errorf("%w: token not issued for %s", ErrInvalidToken, v.audience)
+	}
+
+	info := newAuthInfo(claims)
+	info.ExpiresAt = time.Unix(int64(exp), 0)
+	return info, nil
+}
+
+// decodeJWTPart decodes a base64url encoded JSON part of a JWT
+func decodeJWTPart(part string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return fmt.Errorf("malformed JWT: %v", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("malformed JWT: %v", err)
+	}
+	return nil
+}
+
+// verifyJWTSignature verifies the signature of a JWT with the algorithm of its header
+func verifyJWTSignature(alg string, key crypto.PublicKey, signed, signature []byte) bool {
+	var hash crypto.Hash
+	switch alg {
+	case "RS256", "PS256", "ES256":
+		hash = crypto.SHA256
+	case "RS384", "PS384", "ES384":
+		hash = crypto.SHA384
+	case "RS512", "PS512", "ES512":
+		hash = crypto.SHA512
+	case "EdDSA":
+		edKey, ok := key.(ed25519.PublicKey)
+		return ok && ed25519.Verify(edKey, signed, signature)
+	default:
+		return false
+	}
+	h := hash.New()
+	h.Write(signed)
+	digest := h.Sum(nil)
+
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		if strings.HasPrefix(alg, "RS") {
+			return rsa.VerifyPKCS1v15(key, hash, digest, signature) == nil
+		}
+		if strings.HasPrefix(alg, "PS") {
+			return rsa.VerifyPSS(key, hash, digest, signature, nil) == nil
+		}
+	case *ecdsa.PublicKey:
+		size := (key.Curve.Params().BitSize + 7) / 8
+		if !strings.HasPrefix(alg, "ES") || len(signature) != 2*size {
+			return false
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		return ecdsa.Verify(key, digest, r, s)
+	}
+	return false
+}
+
+// hasAudience reports whether an aud claim, a string or an array, contains the audience
+func hasAudience(aud interface{}, audience string) bool {
+	switch aud := aud.(type) {
+	case string:
+		return aud == audience
+	case []interface{}:
+		for _, a := range aud {
+			if a == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// newAuthInfo creates the information of a token from its claims
+func newAuthInfo(claims map[string]interface{}) *AuthInfo {
+	info := &AuthInfo{Claims: claims}
+	info.Subject, _ = claims["sub"].(string)
+	info.ClientID, _ = claims["client_id"].(string)
+	if info.ClientID == "" {
+		info.ClientID, _ = claims["azp"].(string)
+	}
+	switch aud := claims["aud"].(type) {
+	case string:
+		info.Audience = []string{aud}
+	case []interface{}:
+		for _, a := range aud {
+			if a, ok := a.(string); ok {
+				info.Audience = append(info.Audience, a)
+			}
+		}
+	}
+	if scope, ok := claims["scope"].(string); ok {
+		info.Scopes = strings.Fields(scope)
+	}
+	if scp, ok := claims["scp"].([]interface{}); ok && len(info.Scopes) == 0 {
+		for _, s := range scp {
+			if s, ok := s.(string); ok {
+				info.Scopes = append(info.Scopes, s)
+			}
+		}
+	}
+	return info
+}
+
+// IntrospectionVerifier verifies opaque access tokens with the token introspection endpoint of an
+// authorization server (RFC 7662)
+type IntrospectionVerifier struct {
+	endpoint     string
+	clientID     string
+	clientSecret string
+	audience     string
+	httpClient   *http.Client
+}
+
+// IntrospectionVerifierOption configures an introspection verifier
+type IntrospectionVerifierOption func(*IntrospectionVerifier)
+
+// WithIntrospectionClientCredentials sets the credentials the server authenticates with at the endpoint
+func WithIntrospectionClientCredentials(clientID, clientSecret string) IntrospectionVerifierOption {
+	return func(v *IntrospectionVerifier) {
+		v.clientID = clientID
+		v.clientSecret = clientSecret
+	}
+}
+
+// WithIntrospectionAudience requires the aud of tokens to contain the audience, usually the resource URL of the server
+func WithIntrospectionAudience(audience string) IntrospectionVerifierOption {
+	return func(v *IntrospectionVerifier) {
+		v.audience = audience
+	}
+}
+
+// WithIntrospectionHTTPClient sets the HTTP client used to call the endpoint
+func WithIntrospectionHTTPClient(client *http.Client) IntrospectionVerifierOption {
+	return func(v *IntrospectionVerifier) {
+		v.httpClient = client
+	}
+}
+
+// NewIntrospectionVerifier creates a verifier calling the token introspection endpoint
+func NewIntrospectionVerifier(endpoint string, opts ...IntrospectionVerifierOption) *IntrospectionVerifier {
+	v := &IntrospectionVerifier{
+		endpoint:   endpoint,
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// VerifyToken implements TokenVerifier
+func (v *IntrospectionVerifier) VerifyToken(ctx context.Context, token string) (*AuthInfo, error) {
+	form := url.Values{"token": {token}, "token_type_hint": {"access_token"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create introspection request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if v.clientID != "" {
+		req.SetBasicAuth(url.QueryEscape(v.clientID), url.QueryEscape(v.clientSecret))
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("introspection request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, resp.Body)
+		return nil, fmt.Errorf("introspection request failed with status %d", resp.StatusCode)
+	}
+	var claims map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&claims); err != nil {
+		return nil, fmt.Errorf("failed to parse introspection response: %w", err)
+	}
+
+	if active, _ := claims["active"].(bool); !active {
+		return nil, fmt.Errorf("%w: token is not active", ErrInvalidToken)
+	}
+	if v.audience != "" && !hasAudience(claims["aud"], v.audience) {
+		return nil, fmt.Errorf("%w: token not issued for %s", ErrInvalidToken, v.audience)
+	}
+
+	info := newAuthInfo(claims)
+	if exp, ok := claims["exp"].(float64); ok {
+		info.ExpiresAt = time.Unix(int64(exp), 0)
+	}
+	return info, nil
+}
@@ -0,0 +1,105 @@
+// Tencent is pleased to support the open source community by making trpc-mcp-go available.
+//
+// Copyright (C) 2025 Tencent.  All rights reserved.
+//
+// trpc-mcp-go is licensed under the Apache License Version 2.0.
+
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"trpc.group/trpc-go/trpc-mcp-go/internal/errors"
+)
+
+// BatchRequest describes a request or notification sent as part of a JSON-RPC batch
+type BatchRequest struct {
+	// Method name
+	Method string
+
+	// Method parameters (optional)
+	Params interface{}
+
+	// Whether the message is a notification, which receives no response
+	Notification bool
+}
+
+// BatchResponse describes the response to a request sent as part of a JSON-RPC batch
+type BatchResponse struct {
+	// Result of the request, nil if the request failed
+	Result json.RawMessage
+
+	// Error response of the request, nil if the request succeeded
+	Error *JSONRPCError
+}
+
+// batchTransport is implemented by transports able to send JSON-RPC batches
+type batchTransport interface {
+	// sendBatch sends the messages as one batch and returns the responses to its requests
+	sendBatch(ctx context.Context, messages []interface{}) ([]json.RawMessage, error)
+}
+
+// Batch sends requests and notifications as one JSON-RPC batch, which the server processes concurrently.
+// It returns one response per element of requests in the same order, notifications getting an empty response.
+// Batching is only available on streamable HTTP and was removed in protocol version 2025-06-18,
+// so the client must negotiate an older version, e.g. with WithProtocolVersion(ProtocolVersion_2025_03_26).
+// Each request passes through the client interceptors, and the batch is sent once every request has
+// been sent or answered by its interceptors.
+func (c *Client) Batch(ctx context.Context, requests []BatchRequest) ([]BatchResponse, error) {
+	// Check if initialized.
+	if !c.initialized {
+		return nil, errors.ErrNotInitialized
+	}
+	t, ok := c.transport.(batchTransport)
+	if !ok {
+		return nil, fmt.Errorf("%w by the client transport", ErrBatchNotSupported)
+	}
+	if len(requests) == 0 {
+		return nil, nil
+	}
+
+	// Build the batch
+	messages := make([]interface{}, len(requests))
+	for i, request := range requests {
+		if request.Notification {
+			params, ok := request.Params.(map[string]interface{})
+			if request.Params != nil && !ok {
+				return nil, fmt.Errorf("%w: notification params must be a map, got %T", errors.ErrInvalidParams, request.Params)
+			}
+			messages[i] = newJSONRPCNotification(*NewNotification(request.Method, params))
+			continue
+		}
+		messages[i] = &JSONRPCRequest{
+			JSONRPC: JSONRPCVersion,
+			ID:      c.requestID.Add(1),
+			Request: Request{
+				Method: request.Method,
+			},
+			Params: request.Params,
+		}
+	}
+
+	rawResponses, err := c.interceptors.sendBatch(ctx, messages, t.sendBatch)
+	if err != nil {
+		return nil, fmt.Errorf("batch request failed: %w", err)
+	}
+
+	responses := make([]BatchResponse, len(requests))
+	for i, raw := range rawResponses {
+		if raw == nil {
+			continue
+		}
+		if isErrorResponse(raw) {
+			errResp, err := parseRawMessageToError(raw)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse error response: %w", err)
+			}
+			responses[i].Error = errResp
+			continue
+		}
+		responses[i].Result = *raw
+	}
+	return responses, nil
+}
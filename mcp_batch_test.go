@@ -0,0 +1,173 @@
+// Tencent is pleased to support the open source community by making trpc-mcp-go available.
+//
+// Copyright (C) 2025 Tencent.  All rights reserved.
+//
+// trpc-mcp-go is licensed under the Apache License Version 2.0.
+
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Batch(t *testing.T) {
+	for _, postSSE := range []bool{true, false} {
+		server := NewServer("Test-Server", "1.0.0", WithPostSSEEnabled(postSSE), WithBatchConcurrency(2))
+		server.RegisterTool(NewTool("greet", WithString("name")), func(ctx context.Context, req *CallToolRequest) (*CallToolResult, error) {
+			name, _ := req.Params.Arguments["name"].(string)
+			return NewTextResult("Hello, " + name), nil
+		})
+		httpServer := httptest.NewServer(server.HTTPHandler())
+
+		client, err := NewClient(httpServer.URL+"/mcp", Implementation{Name: "Test-Client", Version: "1.0.0"},
+			WithProtocolVersion(ProtocolVersion_2025_03_26))
+		require.NoError(t, err)
+		_, err = client.Initialize(context.Background(), &InitializeRequest{})
+		require.NoError(t, err)
+
+		responses, err := client.Batch(context.Background(), []BatchRequest{
+			{Method: MethodToolsCall, Params: map[string]interface{}{"name": "greet", "arguments": map[string]interface{}{"name": "a"}}},
+			{Method: MethodNotificationsRootsListChanged, Notification: true},
+			{Method: "unknown/method"},
+			{Method: MethodToolsCall, Params: map[string]interface{}{"name": "greet", "arguments": map[string]interface{}{"name": "b"}}},
+			{Method: MethodToolsList},
+		})
+		require.NoError(t, err, "postSSE=%v", postSSE)
+		require.Len(t, responses, 5)
+
+		// Responses follow the order of the requests
+		for i, name := range map[int]string{0: "a", 3: "b"} {
+			require.Nil(t, responses[i].Error)
+			result, err := parseCallToolResult(&responses[i].Result)
+			require.NoError(t, err)
+			require.Len(t, result.Content, 1)
+			assert.Equal(t, "Hello, "+name, result.Content[0].(TextContent).Text)
+		}
+		assert.Equal(t, BatchResponse{}, responses[1])
+		require.NotNil(t, responses[2].Error)
+		assert.Equal(t, ErrCodeMethodNotFound, responses[2].Error.Error.Code)
+		assert.Contains(t, string(responses[4].Result), "greet")
+
+		// Batches of notifications are accepted without responses
+		responses, err = client.Batch(context.Background(), []BatchRequest{
+			{Method: MethodNotificationsRootsListChanged, Notification: true},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []BatchResponse{{}}, responses)
+
+		client.Close()
+		httpServer.Close()
+	}
+}
+
+func TestClient_BatchNotSupported(t *testing.T) {
+	_, httpServer := createTestServer()
+	defer httpServer.Close()
+
+	client, err := NewClient(httpServer.URL+"/mcp", Implementation{Name: "Test-Client", Version: "1.0.0"})
+	require.NoError(t, err)
+	defer client.Close()
+	_, err = client.Initialize(context.Background(), &InitializeRequest{})
+	require.NoError(t, err)
+
+	// Batching was removed in 2025-06-18
+	_, err = client.Batch(context.Background(), []BatchRequest{{Method: MethodToolsList}})
+	assert.ErrorIs(t, err, ErrBatchNotSupported)
+}
+
+func TestClient_BatchInterceptor(t *testing.T) {
+	var metaMu sync.Mutex
+	var receivedMeta []interface{}
+	recordMeta := func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, req *ServerRequest) (JSONRPCMessage, error) {
+			if params, ok := req.Params.(map[string]interface{}); ok && req.Method == MethodToolsCall {
+				metaMu.Lock()
+				receivedMeta = append(receivedMeta, params["_meta"])
+				metaMu.Unlock()
+			}
+			return next(ctx, req)
+		}
+	}
+	server := NewServer("Test-Server", "1.0.0", WithMiddleware(recordMeta))
+	server.RegisterTool(NewTool("greet", WithString("name")), func(ctx context.Context, req *CallToolRequest) (*CallToolResult, error) {
+		name, _ := req.Params.Arguments["name"].(string)
+		return NewTextResult("Hello, " + name), nil
+	})
+	httpServer := httptest.NewServer(server.HTTPHandler())
+	defer httpServer.Close()
+
+	// Tags tools/call requests and answers tools/list requests without sending them
+	var mu sync.Mutex
+	var methods []string
+	cached := json.RawMessage(`{"tools":[]}`)
+	interceptor := func(next ClientInvoker) ClientInvoker {
+		return func(ctx context.Context, call *ClientCall) (*json.RawMessage, error) {
+			mu.Lock()
+			methods = append(methods, call.Method)
+			mu.Unlock()
+			switch call.Method {
+			case MethodToolsList:
+				return &cached, nil
+			case MethodToolsCall:
+				call.Meta = map[string]interface{}{"traceId": "abc"}
+			}
+			return next(ctx, call)
+		}
+	}
+	client, err := NewClient(httpServer.URL+"/mcp", Implementation{Name: "Test-Client", Version: "1.0.0"},
+		WithProtocolVersion(ProtocolVersion_2025_03_26), WithClientInterceptor(interceptor))
+	require.NoError(t, err)
+	defer client.Close()
+	_, err = client.Initialize(context.Background(), &InitializeRequest{})
+	require.NoError(t, err)
+
+	mu.Lock()
+	methods = nil
+	mu.Unlock()
+	responses, err := client.Batch(context.Background(), []BatchRequest{
+		{Method: MethodToolsCall, Params: map[string]interface{}{"name": "greet", "arguments": map[string]interface{}{"name": "a"}}},
+		{Method: MethodToolsList},
+		{Method: "unknown/method"},
+	})
+	require.NoError(t, err)
+	require.Len(t, responses, 3)
+
+	result, err := parseCallToolResult(&responses[0].Result)
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	assert.Equal(t, "Hello, a", result.Content[0].(TextContent).Text)
+	assert.Equal(t, BatchResponse{Result: cached}, responses[1])
+	require.NotNil(t, responses[2].Error)
+	assert.Equal(t, ErrCodeMethodNotFound, responses[2].Error.Error.Code)
+
+	mu.Lock()
+	assert.ElementsMatch(t, []string{MethodToolsCall, MethodToolsList, "unknown/method"}, methods)
+	mu.Unlock()
+	metaMu.Lock()
+	assert.Equal(t, []interface{}{map[string]interface{}{"traceId": "abc"}}, receivedMeta)
+	metaMu.Unlock()
+
+	// Errors of an interceptor fail the batch
+	failing := errors.New("rejected")
+	client.interceptors = clientInterceptors{func(next ClientInvoker) ClientInvoker {
+		return func(ctx context.Context, call *ClientCall) (*json.RawMessage, error) {
+			if call.Method == MethodToolsList {
+				return nil, failing
+			}
+			return next(ctx, call)
+		}
+	}}
+	_, err = client.Batch(context.Background(), []BatchRequest{
+		{Method: MethodToolsCall, Params: map[string]interface{}{"name": "greet"}},
+		{Method: MethodToolsList},
+	})
+	assert.ErrorIs(t, err, failing)
+}
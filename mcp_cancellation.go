@@ -0,0 +1,132 @@
+// Tencent is pleased to support the open source community by making trpc-mcp-go available.
+//
+// Copyright (C) 2025 Tencent.  All rights reserved.
+//
+// trpc-mcp-go is licensed under the Apache License Version 2.0.
+
+package mcp
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// cancelledNotificationTimeout bounds the time spent notifying the server about a cancelled request
+const cancelledNotificationTimeout = 5 * time.Second
+
+// CancelledNotification describes a notification that a previously issued request has been cancelled
+// Corresponds to the "CancelledNotification" definition in schema.json
+type CancelledNotification struct {
+	Notification
+	Params struct {
+		// RequestID is the ID of the request to cancel
+		RequestID interface{} `json:"requestId"`
+
+		// Reason is an optional description of why the request was cancelled
+		Reason string `json:"reason,omitempty"`
+	} `json:"params"`
+}
+
+// NewCancelledNotification creates a notification cancelling the request with the given ID
+func NewCancelledNotification(requestID interface{}, reason string) *JSONRPCNotification {
+	params := map[string]interface{}{
+		"requestId": requestID,
+	}
+	if reason != "" {
+		params["reason"] = reason
+	}
+	return newJSONRPCNotification(*NewNotification(MethodNotificationsCancelled, params))
+}
+
+// inFlightRequest is a request being processed whose context can be cancelled
+type inFlightRequest struct {
+	cancel context.CancelFunc
+}
+
+// inFlightRequests tracks the requests being processed, keyed by session ID and request ID,
+// so that notifications/cancelled can cancel the context of the matching handler
+type inFlightRequests struct {
+	// Requests keyed by session ID, then by normalized request ID
+	requests map[string]map[string]*inFlightRequest
+
+	// Mutex for requests map
+	mu sync.Mutex
+}
+
+// newInFlightRequests creates an empty in-flight request registry
+func newInFlightRequests() *inFlightRequests {
+	return &inFlightRequests{
+		requests: make(map[string]map[string]*inFlightRequest),
+	}
+}
+
+// begin registers a request and returns its cancellable context along with a function
+// that must be called once the request has been processed
+func (r *inFlightRequests) begin(ctx context.Context, sessionID string, id interface{}) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	entry := &inFlightRequest{cancel: cancel}
+	key := requestIDKey(id)
+
+	r.mu.Lock()
+	if r.requests[sessionID] == nil {
+		r.requests[sessionID] = make(map[string]*inFlightRequest)
+	}
+	r.requests[sessionID][key] = entry
+	r.mu.Unlock()
+
+	return ctx, func() {
+		r.mu.Lock()
+		// A request reusing the same ID may have replaced this entry.
+		if r.requests[sessionID][key] == entry {
+			delete(r.requests[sessionID], key)
+			if len(r.requests[sessionID]) == 0 {
+				delete(r.requests, sessionID)
+			}
+		}
+		r.mu.Unlock()
+		cancel()
+	}
+}
+
+// cancel cancels the context of an in-flight request, it reports whether the request was found
+func (r *inFlightRequests) cancel(sessionID string, id interface{}) bool {
+	r.mu.Lock()
+	entry, ok := r.requests[sessionID][requestIDKey(id)]
+	r.mu.Unlock()
+
+	if !ok {
+		return false
+	}
+	entry.cancel()
+	return true
+}
+
+// cancelSession cancels all in-flight requests of a session
+func (r *inFlightRequests) cancelSession(sessionID string) {
+	r.mu.Lock()
+	entries := r.requests[sessionID]
+	delete(r.requests, sessionID)
+	r.mu.Unlock()
+
+	for _, entry := range entries {
+		entry.cancel()
+	}
+}
+
+// handleCancelledNotification cancels the request referenced by a notifications/cancelled message
+func (r *inFlightRequests) handleCancelledNotification(notification *JSONRPCNotification, session Session) {
+	requestID, ok := notification.Params.AdditionalFields["requestId"]
+	if !ok || requestID == nil {
+		return
+	}
+	r.cancel(getSessionID(session), requestID)
+}
+
+// getSessionID returns the ID of a session, or an empty string if there is no session
+func getSessionID(session Session) string {
+	if session == nil {
+		return ""
+	}
+	return session.GetID()
+}
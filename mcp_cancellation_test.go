@@ -0,0 +1,83 @@
+// Tencent is pleased to support the open source community by making trpc-mcp-go available.
+//
+// Copyright (C) 2025 Tencent.  All rights reserved.
+//
+// trpc-mcp-go is licensed under the Apache License Version 2.0.
+
+package mcp
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInFlightRequests(t *testing.T) {
+	registry := newInFlightRequests()
+
+	// Numeric IDs match regardless of their JSON decoding
+	ctx1, done1 := registry.begin(context.Background(), "session-1", int64(1))
+	defer done1()
+	assert.True(t, registry.cancel("session-1", float64(1)))
+	assert.ErrorIs(t, ctx1.Err(), context.Canceled)
+
+	// Requests of other sessions are not affected
+	ctx2, done2 := registry.begin(context.Background(), "session-2", "req-1")
+	assert.False(t, registry.cancel("session-1", "req-1"))
+	assert.NoError(t, ctx2.Err())
+
+	// Finished requests are removed from the registry
+	done2()
+	assert.False(t, registry.cancel("session-2", "req-1"))
+
+	// Terminating a session cancels all its requests
+	ctx3, done3 := registry.begin(context.Background(), "session-3", 1)
+	defer done3()
+	ctx4, done4 := registry.begin(context.Background(), "session-3", 2)
+	defer done4()
+	registry.cancelSession("session-3")
+	assert.Error(t, ctx3.Err())
+	assert.Error(t, ctx4.Err())
+}
+
+func TestSSEClient_CallToolCancellation(t *testing.T) {
+	handlerCancelled := make(chan struct{})
+	server := NewSSEServer("Test-SSE-Server", "1.0.0")
+	server.RegisterTool(NewTool("slow"), func(ctx context.Context, req *CallToolRequest) (*CallToolResult, error) {
+		select {
+		case <-ctx.Done():
+			close(handlerCancelled)
+			return nil, ctx.Err()
+		case <-time.After(10 * time.Second):
+			return NewTextResult("done"), nil
+		}
+	})
+
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	client, err := NewSSEClient(httpServer.URL+"/sse", Implementation{Name: "Test-Client", Version: "1.0.0"})
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = client.Initialize(ctx, &InitializeRequest{})
+	require.NoError(t, err)
+
+	// Giving up on the call notifies the server, which cancels the handler context
+	callCtx, callCancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer callCancel()
+	_, err = client.CallTool(callCtx, &CallToolRequest{Params: CallToolParams{Name: "slow"}})
+	require.Error(t, err)
+
+	select {
+	case <-handlerCancelled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("tool handler was not cancelled")
+	}
+}
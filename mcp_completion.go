@@ -0,0 +1,151 @@
+// Tencent is pleased to support the open source community by making trpc-mcp-go available.
+//
+// Copyright (C) 2025 Tencent.  All rights reserved.
+//
+// trpc-mcp-go is licensed under the Apache License Version 2.0.
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"trpc.group/trpc-go/trpc-mcp-go/internal/errors"
+)
+
+// Completion reference types
+const (
+	// RefTypePrompt references a prompt by name
+	RefTypePrompt = "ref/prompt"
+
+	// RefTypeResource references a resource or resource template by URI
+	RefTypeResource = "ref/resource"
+)
+
+// maxCompletionValues is the maximum number of values in a completion result
+const maxCompletionValues = 100
+
+// CompletionProvider suggests values for a prompt argument or a resource template variable.
+// It returns the matching values, the total number of matches if known (zero otherwise),
+// and whether there are more matches than the returned values.
+type CompletionProvider func(ctx context.Context, argName, partialValue string) (values []string, total int, hasMore bool)
+
+// CompleteReference identifies the prompt or resource template being completed
+type CompleteReference struct {
+	// Type is either RefTypePrompt or RefTypeResource
+	Type string `json:"type"`
+
+	// Name is the name of the prompt, for RefTypePrompt
+	Name string `json:"name,omitempty"`
+
+	// URI is the URI template of the resource, for RefTypeResource
+	URI string `json:"uri,omitempty"`
+}
+
+// CompleteArgument is the argument being completed
+type CompleteArgument struct {
+	// Name of the argument
+	Name string `json:"name"`
+
+	// Value entered so far
+	Value string `json:"value"`
+}
+
+// CompleteContext carries additional information for completion
+type CompleteContext struct {
+	// Arguments already resolved in the prompt or URI template
+	Arguments map[string]string `json:"arguments,omitempty"`
+}
+
+// CompleteRequest describes a request from the client to complete an argument
+// Corresponds to the "CompleteRequest" definition in schema.json
+type CompleteRequest struct {
+	Request
+	Params struct {
+		Ref      CompleteReference `json:"ref"`
+		Argument CompleteArgument  `json:"argument"`
+		Context  *CompleteContext  `json:"context,omitempty"`
+	} `json:"params"`
+}
+
+// CompleteResult describes the server's response to a completion/complete request
+// Corresponds to the "CompleteResult" definition in schema.json
+type CompleteResult struct {
+	Result
+	Completion struct {
+		// Values are the completion values, at most 100 items
+		Values []string `json:"values"`
+
+		// Total is the total number of completion options available
+		Total int `json:"total,omitempty"`
+
+		// HasMore indicates there are more options than the returned values
+		HasMore bool `json:"hasMore,omitempty"`
+	} `json:"completion"`
+}
+
+// parseCompleteParams parses and validates the parameters of a completion/complete request
+func parseCompleteParams(req *JSONRPCRequest) (*CompleteRequest, JSONRPCMessage) {
+	paramsMap, ok := req.Params.(map[string]interface{})
+	if !ok {
+		return nil, newJSONRPCErrorResponse(req.ID, ErrCodeInvalidParams, errors.ErrInvalidParams.Error(), nil)
+	}
+	ref, ok := paramsMap["ref"].(map[string]interface{})
+	if !ok {
+		return nil, newJSONRPCErrorResponse(req.ID, ErrCodeInvalidParams, errors.ErrMissingParams.Error(), nil)
+	}
+	argument, ok := paramsMap["argument"].(map[string]interface{})
+	if !ok {
+		return nil, newJSONRPCErrorResponse(req.ID, ErrCodeInvalidParams, errors.ErrMissingParams.Error(), nil)
+	}
+
+	completeReq := &CompleteRequest{}
+	completeReq.Method = MethodCompletionComplete
+	completeReq.Params.Ref.Type, _ = ref["type"].(string)
+	completeReq.Params.Ref.Name, _ = ref["name"].(string)
+	completeReq.Params.Ref.URI, _ = ref["uri"].(string)
+	completeReq.Params.Argument.Name, _ = argument["name"].(string)
+	completeReq.Params.Argument.Value, _ = argument["value"].(string)
+
+	switch {
+	case completeReq.Params.Ref.Type == RefTypePrompt && completeReq.Params.Ref.Name == "",
+		completeReq.Params.Ref.Type == RefTypeResource && completeReq.Params.Ref.URI == "",
+		completeReq.Params.Argument.Name == "":
+		return nil, newJSONRPCErrorResponse(req.ID, ErrCodeInvalidParams, errors.ErrMissingParams.Error(), nil)
+	case completeReq.Params.Ref.Type != RefTypePrompt && completeReq.Params.Ref.Type != RefTypeResource:
+		return nil, newJSONRPCErrorResponse(req.ID, ErrCodeInvalidParams,
+			fmt.Sprintf("%v: unsupported reference type %q", errors.ErrInvalidParams, completeReq.Params.Ref.Type), nil)
+	}
+
+	if contextMap, ok := paramsMap["context"].(map[string]interface{}); ok {
+		completeReq.Params.Context = &CompleteContext{Arguments: make(map[string]string)}
+		arguments, _ := contextMap["arguments"].(map[string]interface{})
+		for k, v := range arguments {
+			if str, ok := v.(string); ok {
+				completeReq.Params.Context.Arguments[k] = str
+			}
+		}
+	}
+	return completeReq, nil
+}
+
+// complete runs a completion provider and builds the result, a nil provider yields no values
+func complete(ctx context.Context, provider CompletionProvider, argument CompleteArgument) *CompleteResult {
+	result := &CompleteResult{}
+	result.Completion.Values = []string{}
+	if provider == nil {
+		return result
+	}
+
+	values, total, hasMore := provider(ctx, argument.Name, argument.Value)
+	if len(values) > maxCompletionValues {
+		values = values[:maxCompletionValues]
+		hasMore = true
+	}
+	if values != nil {
+		result.Completion.Values = values
+	}
+	result.Completion.Total = total
+	result.Completion.HasMore = hasMore
+	return result
+}
@@ -0,0 +1,122 @@
+// Tencent is pleased to support the open source community by making trpc-mcp-go available.
+//
+// Copyright (C) 2025 Tencent.  All rights reserved.
+//
+// trpc-mcp-go is licensed under the Apache License Version 2.0.
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// prefixCompletion completes values starting with the partial value
+func prefixCompletion(candidates ...string) CompletionProvider {
+	return func(ctx context.Context, argName, partialValue string) ([]string, int, bool) {
+		var values []string
+		for _, candidate := range candidates {
+			if strings.HasPrefix(candidate, partialValue) {
+				values = append(values, candidate)
+			}
+		}
+		return values, len(values), false
+	}
+}
+
+func TestComplete_TruncatesValues(t *testing.T) {
+	provider := func(ctx context.Context, argName, partialValue string) ([]string, int, bool) {
+		values := make([]string, 150)
+		for i := range values {
+			values[i] = fmt.Sprintf("%s-%d", partialValue, i)
+		}
+		return values, len(values), false
+	}
+
+	result := complete(context.Background(), provider, CompleteArgument{Name: "arg", Value: "v"})
+	assert.Len(t, result.Completion.Values, maxCompletionValues)
+	assert.Equal(t, 150, result.Completion.Total)
+	assert.True(t, result.Completion.HasMore)
+
+	// Arguments without a provider have no values
+	result = complete(context.Background(), nil, CompleteArgument{Name: "arg"})
+	assert.NotNil(t, result.Completion.Values)
+	assert.Empty(t, result.Completion.Values)
+}
+
+func TestClient_Complete(t *testing.T) {
+	server := NewServer("Test-Server", "1.0.0", WithServerPath("/mcp"))
+	server.RegisterPrompt(&Prompt{
+		Name: "code_review",
+		Arguments: []PromptArgument{
+			{Name: "language", Completion: prefixCompletion("go", "golang", "python")},
+			{Name: "style"},
+		},
+	}, nil)
+	server.RegisterResourceTemplate(
+		NewResourceTemplate("file:///repos/{owner}/{repo}", "repos",
+			WithTemplateCompletion("owner", prefixCompletion("trpc-group", "tencent"))),
+		func(ctx context.Context, req *ReadResourceRequest) ([]ResourceContents, error) {
+			return nil, nil
+		},
+	)
+
+	httpServer := httptest.NewServer(server.HTTPHandler())
+	defer httpServer.Close()
+
+	client, err := NewClient(httpServer.URL+"/mcp", Implementation{Name: "Test-Client", Version: "1.0.0"})
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	initResult, err := client.Initialize(ctx, &InitializeRequest{})
+	require.NoError(t, err)
+	assert.NotNil(t, initResult.Capabilities.Completions)
+
+	newCompleteRequest := func(ref CompleteReference, name, value string) *CompleteRequest {
+		req := &CompleteRequest{}
+		req.Params.Ref = ref
+		req.Params.Argument = CompleteArgument{Name: name, Value: value}
+		return req
+	}
+
+	// Prompt arguments
+	result, err := client.Complete(ctx, newCompleteRequest(
+		CompleteReference{Type: RefTypePrompt, Name: "code_review"}, "language", "go"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go", "golang"}, result.Completion.Values)
+	assert.Equal(t, 2, result.Completion.Total)
+
+	result, err = client.Complete(ctx, newCompleteRequest(
+		CompleteReference{Type: RefTypePrompt, Name: "code_review"}, "style", "a"))
+	require.NoError(t, err)
+	assert.Empty(t, result.Completion.Values)
+
+	// Resource template variables
+	result, err = client.Complete(ctx, newCompleteRequest(
+		CompleteReference{Type: RefTypeResource, URI: "file:///repos/{owner}/{repo}"}, "owner", "t"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"trpc-group", "tencent"}, result.Completion.Values)
+
+	// Unknown references and arguments are rejected
+	_, err = client.Complete(ctx, newCompleteRequest(
+		CompleteReference{Type: RefTypePrompt, Name: "missing"}, "language", ""))
+	assert.Error(t, err)
+	_, err = client.Complete(ctx, newCompleteRequest(
+		CompleteReference{Type: RefTypePrompt, Name: "code_review"}, "missing", ""))
+	assert.Error(t, err)
+	_, err = client.Complete(ctx, newCompleteRequest(
+		CompleteReference{Type: RefTypeResource, URI: "file:///missing/{id}"}, "id", ""))
+	assert.Error(t, err)
+	_, err = client.Complete(ctx, newCompleteRequest(
+		CompleteReference{Type: "ref/unknown", Name: "code_review"}, "language", ""))
+	assert.Error(t, err)
+}
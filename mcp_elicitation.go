@@ -0,0 +1,93 @@
+// Tencent is pleased to support the open source community by making trpc-mcp-go available.
+//
+// Copyright (C) 2025 Tencent.  All rights reserved.
+//
+// trpc-mcp-go is licensed under the Apache License Version 2.0.
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ElicitAction describes how the user responded to an elicitation request
+type ElicitAction string
+
+const (
+	// ElicitActionAccept indicates the user submitted the requested data
+	ElicitActionAccept ElicitAction = "accept"
+
+	// ElicitActionDecline indicates the user explicitly declined the request
+	ElicitActionDecline ElicitAction = "decline"
+
+	// ElicitActionCancel indicates the user dismissed the request without making a choice
+	ElicitActionCancel ElicitAction = "cancel"
+)
+
+// ElicitParams describes the parameters of an elicitation/create request
+// Corresponds to schema.json ElicitRequest.params
+type ElicitParams struct {
+	// Message is presented to the user to explain what information is requested
+	Message string `json:"message"`
+
+	// RequestedSchema is a flat object schema whose properties are primitive types
+	RequestedSchema *openapi3.Schema `json:"requestedSchema"`
+}
+
+// ElicitRequest describes a request from the server to elicit additional information from the user
+// Corresponds to the "ElicitRequest" definition in schema.json
+type ElicitRequest struct {
+	Request
+	Params ElicitParams `json:"params"`
+}
+
+// ElicitResult describes the client's response to an elicitation/create request
+// Corresponds to the "ElicitResult" definition in schema.json
+type ElicitResult struct {
+	Result
+
+	// Action is the user's response to the request
+	Action ElicitAction `json:"action"`
+
+	// Content holds the submitted data, only present when Action is accept
+	Content map[string]interface{} `json:"content,omitempty"`
+}
+
+// NewElicitationSchema creates an object schema for elicitation requests.
+// Properties are declared with the same options used for tool input schemas, e.g. WithString.
+func NewElicitationSchema(opts ...ToolOption) *openapi3.Schema {
+	tool := NewTool("", opts...)
+	return tool.InputSchema
+}
+
+// RequestElicitation asks the client connected to the current session for additional user input.
+// It must be called with the context passed to a tool (or other request) handler, and
+// the client must have advertised the elicitation capability during initialization.
+func RequestElicitation(ctx context.Context, params *ElicitParams) (*ElicitResult, error) {
+	if params == nil {
+		return nil, fmt.Errorf("elicitation params cannot be nil")
+	}
+	if params.RequestedSchema == nil {
+		return nil, fmt.Errorf("elicitation requested schema cannot be nil")
+	}
+
+	capabilities, ok := getClientCapabilitiesFromContext(ctx)
+	if !ok || capabilities.Elicitation == nil {
+		return nil, fmt.Errorf("%w: elicitation", ErrClientCapabilityNotSupported)
+	}
+
+	var result ElicitResult
+	if err := requestClient(ctx, MethodElicitationCreate, params, &result); err != nil {
+		return nil, err
+	}
+
+	switch result.Action {
+	case ElicitActionAccept, ElicitActionDecline, ElicitActionCancel:
+		return &result, nil
+	default:
+		return nil, fmt.Errorf("invalid elicitation action: %q", result.Action)
+	}
+}
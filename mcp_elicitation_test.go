@@ -0,0 +1,77 @@
+// Tencent is pleased to support the open source community by making trpc-mcp-go available.
+//
+// Copyright (C) 2025 Tencent.  All rights reserved.
+//
+// trpc-mcp-go is licensed under the Apache License Version 2.0.
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestElicitation_WithoutCapability(t *testing.T) {
+	ctx := setSessionToContext(context.Background(), newSession())
+
+	_, err := RequestElicitation(ctx, &ElicitParams{
+		Message:         "Your name?",
+		RequestedSchema: NewElicitationSchema(WithString("name")),
+	})
+	assert.ErrorIs(t, err, ErrClientCapabilityNotSupported)
+}
+
+func TestClient_RegisterElicitationHandler(t *testing.T) {
+	server := NewServer("Test-Server", "1.0.0", WithServerPath("/mcp"))
+	server.RegisterTool(NewTool("greet"), func(ctx context.Context, req *CallToolRequest) (*CallToolResult, error) {
+		result, err := RequestElicitation(ctx, &ElicitParams{
+			Message:         "Your name?",
+			RequestedSchema: NewElicitationSchema(WithString("name", Required())),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if result.Action != ElicitActionAccept {
+			return NewTextResult(string(result.Action)), nil
+		}
+		return NewTextResult(fmt.Sprintf("hello %v", result.Content["name"])), nil
+	})
+
+	httpServer := httptest.NewServer(server.HTTPHandler())
+	defer httpServer.Close()
+
+	client, err := NewClient(httpServer.URL+"/mcp", Implementation{Name: "Test-Client", Version: "1.0.0"})
+	require.NoError(t, err)
+	defer client.Close()
+
+	action := ElicitActionAccept
+	client.RegisterElicitationHandler(func(ctx context.Context, req *ElicitRequest) (*ElicitResult, error) {
+		assert.Equal(t, "Your name?", req.Params.Message)
+		require.NotNil(t, req.Params.RequestedSchema)
+		assert.Equal(t, []string{"name"}, req.Params.RequestedSchema.Required)
+		if action != ElicitActionAccept {
+			return &ElicitResult{Action: action}, nil
+		}
+		return &ElicitResult{Action: action, Content: map[string]interface{}{"name": "alice"}}, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err = client.Initialize(ctx, &InitializeRequest{})
+	require.NoError(t, err)
+
+	result, err := client.CallTool(ctx, &CallToolRequest{Params: CallToolParams{Name: "greet"}})
+	require.NoError(t, err)
+	assert.Equal(t, "hello alice", result.Content[0].(TextContent).Text)
+
+	action = ElicitActionDecline
+	result, err = client.CallTool(ctx, &CallToolRequest{Params: CallToolParams{Name: "greet"}})
+	require.NoError(t, err)
+	assert.Equal(t, "decline", result.Content[0].(TextContent).Text)
+}
@@ -0,0 +1,297 @@
+// Tencent is pleased to support the open source community by making trpc-mcp-go available.
+//
+// Copyright (C) 2025 Tencent.  All rights reserved.
+//
+// trpc-mcp-go is licensed under the Apache License Version 2.0.
+
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// ClientCall describes a request sent by a client or a notification received by it
+type ClientCall struct {
+	// Method name
+	Method string
+
+	// Request ID, nil for notifications
+	ID RequestId
+
+	// Method parameters, NotificationParams for notifications.
+	// Interceptors may replace them with a value of the same type before calling the next invoker.
+	Params interface{}
+
+	// Fields added to the _meta of the request params when the request is sent, e.g. for tracing
+	Meta map[string]interface{}
+
+	// Whether the call is a received notification, which has no response
+	Notification bool
+}
+
+// ClientInvoker sends a request and returns its raw response, or delivers a received notification
+// to its handler and returns a nil response. The response of a request rejected by the server is a
+// JSON-RPC error, see ParseErrorResponse.
+type ClientInvoker func(ctx context.Context, call *ClientCall) (*json.RawMessage, error)
+
+// ClientInterceptor wraps every request sent by a client, batched ones included, and every
+// notification received by it, e.g. for logging, retries, tracing or per-method timeouts.
+// The context of a notification is not bound to a request.
+//
+// Example:
+//
+//	func timeoutInterceptor(next mcp.ClientInvoker) mcp.ClientInvoker {
+//	    return func(ctx context.Context, call *mcp.ClientCall) (*json.RawMessage, error) {
+//	        if call.Method == mcp.MethodToolsCall {
+//	            var cancel context.CancelFunc
+//	            ctx, cancel = context.WithTimeout(ctx, 10*time.Second)
+//	            defer cancel()
+//	        }
+//	        return next(ctx, call)
+//	    }
+//	}
+type ClientInterceptor func(next ClientInvoker) ClientInvoker
+
+// ParseErrorResponse returns the JSON-RPC error of a raw response, nil if the request succeeded
+func ParseErrorResponse(rawResp *json.RawMessage) *JSONRPCError {
+	if rawResp == nil || !isErrorResponse(rawResp) {
+		return nil
+	}
+	errResp, err := parseRawMessageToError(rawResp)
+	if err != nil {
+		return nil
+	}
+	return errResp
+}
+
+// clientInterceptors is the chain of interceptors of a client, the first one being the outermost
+type clientInterceptors []ClientInterceptor
+
+// interceptorTransport is implemented by client transports passing received notifications through interceptors
+type interceptorTransport interface {
+	// setInterceptors sets the interceptors of received notifications
+	setInterceptors(interceptors clientInterceptors)
+}
+
+// chain wraps invoker with the interceptors
+func (i clientInterceptors) chain(invoker ClientInvoker) ClientInvoker {
+	for j := len(i) - 1; j >= 0; j-- {
+		invoker = i[j](invoker)
+	}
+	return invoker
+}
+
+// sendRequest sends a request with send through the interceptors
+func (i clientInterceptors) sendRequest(
+	ctx context.Context,
+	req *JSONRPCRequest,
+	send func(ctx context.Context, req *JSONRPCRequest) (*json.RawMessage, error),
+) (*json.RawMessage, error) {
+	if len(i) == 0 {
+		return send(ctx, req)
+	}
+
+	invoker := func(ctx context.Context, call *ClientCall) (*json.RawMessage, error) {
+		sent := *req
+		sent.Params = call.Params
+		if len(call.Meta) > 0 {
+			params, err := withParamsMeta(call.Params, call.Meta)
+			if err != nil {
+				return nil, err
+			}
+			sent.Params = params
+		}
+		return send(ctx, &sent)
+	}
+	return i.chain(invoker)(ctx, &ClientCall{
+		Method: req.Method,
+		ID:     req.ID,
+		Params: req.Params,
+	})
+}
+
+// batchCall is a request of a batch sent by its interceptors, with no request if they answered it themselves
+type batchCall struct {
+	request  *JSONRPCRequest
+	response chan batchResult
+}
+
+// batchResult is the response to a request of a batch
+type batchResult struct {
+	raw *json.RawMessage
+	err error
+}
+
+// sendBatch sends a batch with send, passing each of its requests through the interceptors.
+// It returns one response per message in the same order: nil for notifications, the result of
+// succeeded requests and the error response of failed ones, as for single requests.
+func (i clientInterceptors) sendBatch(
+	ctx context.Context,
+	messages []interface{},
+	send func(ctx context.Context, messages []interface{}) ([]json.RawMessage, error),
+) ([]*json.RawMessage, error) {
+	results := make([]batchResult, len(messages))
+	calls := make(chan batchCall)
+	var wg sync.WaitGroup
+	for index, message := range messages {
+		req, ok := message.(*JSONRPCRequest)
+		if !ok {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sent := false
+			raw, err := i.sendRequest(ctx, req, func(ctx context.Context, req *JSONRPCRequest) (*json.RawMessage, error) {
+				if sent {
+					return nil, fmt.Errorf("request %v of the batch was already sent", req.ID)
+				}
+				sent = true
+				call := batchCall{request: req, response: make(chan batchResult, 1)}
+				calls <- call
+				result := <-call.response
+				return result.raw, result.err
+			})
+			if !sent {
+				calls <- batchCall{}
+			}
+			results[index] = batchResult{raw: raw, err: err}
+		}()
+	}
+
+	// Wait for every request to be sent or answered by its interceptors
+	var batch []interface{}
+	waiting := make(map[string]batchCall)
+	for _, message := range messages {
+		if _, ok := message.(*JSONRPCRequest); !ok {
+			batch = append(batch, message)
+			continue
+		}
+		if call := <-calls; call.request != nil {
+			batch = append(batch, call.request)
+			waiting[fmt.Sprintf("%v", call.request.ID)] = call
+		}
+	}
+	err := respondBatch(ctx, batch, waiting, send)
+	wg.Wait()
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*json.RawMessage, len(messages))
+	for index, result := range results {
+		if result.err != nil {
+			return nil, fmt.Errorf("request %s failed: %w", messages[index].(*JSONRPCRequest).Method, result.err)
+		}
+		responses[index] = result.raw
+	}
+	return responses, nil
+}
+
+// respondBatch sends a batch with send and delivers the responses to the waiting requests by ID
+func respondBatch(
+	ctx context.Context,
+	batch []interface{},
+	waiting map[string]batchCall,
+	send func(ctx context.Context, messages []interface{}) ([]json.RawMessage, error),
+) (err error) {
+	defer func() {
+		// Release the requests left without a response
+		for _, call := range waiting {
+			call.response <- batchResult{err: err}
+		}
+	}()
+	if len(batch) == 0 {
+		return nil
+	}
+
+	rawResponses, err := send(ctx, batch)
+	if err != nil {
+		return err
+	}
+	for _, raw := range rawResponses {
+		var resp struct {
+			ID     interface{}     `json:"id"`
+			Result json.RawMessage `json:"result"`
+		}
+		if err := json.Unmarshal(raw, &resp); err != nil {
+			return fmt.Errorf("%w: %v", ErrResponseParsing, err)
+		}
+		id := fmt.Sprintf("%v", resp.ID)
+		call, ok := waiting[id]
+		if !ok {
+			// Errors without a matching ID concern the batch itself
+			if isErrorResponse(&raw) {
+				errResp, err := parseRawMessageToError(&raw)
+				if err != nil {
+					return fmt.Errorf("failed to parse error response: %w", err)
+				}
+				return fmt.Errorf("batch error: %s (code: %d)", errResp.Error.Message, errResp.Error.Code)
+			}
+			continue
+		}
+		delete(waiting, id)
+		if isErrorResponse(&raw) {
+			call.response <- batchResult{raw: &raw}
+		} else {
+			call.response <- batchResult{raw: &resp.Result}
+		}
+	}
+	return nil
+}
+
+// handleNotification delivers a received notification with deliver through the interceptors
+func (i clientInterceptors) handleNotification(
+	notification *JSONRPCNotification,
+	deliver func(notification *JSONRPCNotification) error,
+) error {
+	if len(i) == 0 {
+		return deliver(notification)
+	}
+
+	invoker := func(ctx context.Context, call *ClientCall) (*json.RawMessage, error) {
+		delivered := *notification
+		if params, ok := call.Params.(NotificationParams); ok {
+			delivered.Params = params
+		}
+		return nil, deliver(&delivered)
+	}
+	_, err := i.chain(invoker)(context.Background(), &ClientCall{
+		Method:       notification.Method,
+		Params:       notification.Params,
+		Notification: true,
+	})
+	return err
+}
+
+// withParamsMeta returns request params with fields added to their _meta
+func withParamsMeta(params interface{}, meta map[string]interface{}) (map[string]interface{}, error) {
+	paramsMap := map[string]interface{}{}
+	if params != nil {
+		data, err := json.Marshal(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request params: %w", err)
+		}
+		if err := json.Unmarshal(data, &paramsMap); err != nil {
+			return nil, fmt.Errorf("request params must be an object to carry _meta: %w", err)
+		}
+		if paramsMap == nil {
+			paramsMap = map[string]interface{}{}
+		}
+	}
+
+	merged := map[string]interface{}{}
+	if existing, ok := paramsMap["_meta"].(map[string]interface{}); ok {
+		for k, v := range existing {
+			merged[k] = v
+		}
+	}
+	for k, v := range meta {
+		merged[k] = v
+	}
+	paramsMap["_meta"] = merged
+	return paramsMap, nil
+}
@@ -0,0 +1,158 @@
+// Tencent is pleased to support the open source community by making trpc-mcp-go available.
+//
+// Copyright (C) 2025 Tencent.  All rights reserved.
+//
+// trpc-mcp-go is licensed under the Apache License Version 2.0.
+
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Interceptor(t *testing.T) {
+	// Records the _meta of tools/call requests received by the server
+	var metaMu sync.Mutex
+	var receivedMeta interface{}
+	recordMeta := func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, req *ServerRequest) (JSONRPCMessage, error) {
+			if params, ok := req.Params.(map[string]interface{}); ok && req.Method == MethodToolsCall {
+				metaMu.Lock()
+				receivedMeta = params["_meta"]
+				metaMu.Unlock()
+			}
+			return next(ctx, req)
+		}
+	}
+	logTool := func(ctx context.Context, req *CallToolRequest) (*CallToolResult, error) {
+		GetClientLogger(ctx).Info("working")
+		return NewTextResult("done"), nil
+	}
+
+	testCases := []struct {
+		name      string
+		newClient func(t *testing.T, interceptor ClientInterceptor) *Client
+	}{
+		{
+			name: "streamable HTTP",
+			newClient: func(t *testing.T, interceptor ClientInterceptor) *Client {
+				server := NewServer("Test-Server", "1.0.0", WithServerPath("/mcp"), WithMiddleware(recordMeta))
+				server.RegisterTool(NewTool("log"), logTool)
+				httpServer := httptest.NewServer(server.HTTPHandler())
+				t.Cleanup(httpServer.Close)
+				client, err := NewClient(httpServer.URL+"/mcp", Implementation{Name: "Test-Client", Version: "1.0.0"},
+					WithClientInterceptor(interceptor))
+				require.NoError(t, err)
+				return client
+			},
+		},
+		{
+			name: "SSE",
+			newClient: func(t *testing.T, interceptor ClientInterceptor) *Client {
+				server := NewSSEServer("Test-SSE-Server", "1.0.0", WithSSEMiddleware(recordMeta))
+				server.RegisterTool(NewTool("log"), logTool)
+				httpServer := httptest.NewServer(server)
+				t.Cleanup(httpServer.Close)
+				client, err := NewSSEClient(httpServer.URL+"/sse", Implementation{Name: "Test-Client", Version: "1.0.0"},
+					WithClientInterceptor(interceptor))
+				require.NoError(t, err)
+				return client
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var requests, notifications []string
+			interceptor := func(next ClientInvoker) ClientInvoker {
+				return func(ctx context.Context, call *ClientCall) (*json.RawMessage, error) {
+					if call.Notification {
+						mu.Lock()
+						notifications = append(notifications, call.Method)
+						mu.Unlock()
+						return next(ctx, call)
+					}
+					if call.Method == MethodPing {
+						return nil, errors.New("ping disabled")
+					}
+					if call.Method == MethodToolsCall {
+						call.Meta = map[string]interface{}{"traceparent": "00-trace-01"}
+					}
+					start := time.Now()
+					rawResp, err := next(ctx, call)
+					assert.Greater(t, time.Since(start), time.Duration(0))
+					mu.Lock()
+					requests = append(requests, call.Method)
+					mu.Unlock()
+					return rawResp, err
+				}
+			}
+			client := tc.newClient(t, interceptor)
+			defer client.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_, err := client.Initialize(ctx, &InitializeRequest{})
+			require.NoError(t, err)
+
+			// Requests go through the interceptor, which can add fields to _meta
+			result, err := client.CallTool(ctx, &CallToolRequest{Params: CallToolParams{Name: "log"}})
+			require.NoError(t, err)
+			assert.Equal(t, "done", result.Content[0].(TextContent).Text)
+			metaMu.Lock()
+			assert.Equal(t, map[string]interface{}{"traceparent": "00-trace-01"}, receivedMeta)
+			metaMu.Unlock()
+
+			// Received notifications go through the interceptor
+			assert.Eventually(t, func() bool {
+				mu.Lock()
+				defer mu.Unlock()
+				return len(notifications) == 1 && notifications[0] == NotificationMethodMessage
+			}, time.Second, 10*time.Millisecond)
+
+			// The interceptor can fail requests without sending them
+			_, err = client.sendRequest(ctx, newJSONRPCRequest(99, MethodPing, nil))
+			assert.EqualError(t, err, "ping disabled")
+
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Equal(t, []string{MethodInitialize, MethodToolsCall}, requests)
+		})
+	}
+}
+
+func TestWithParamsMeta(t *testing.T) {
+	params, err := withParamsMeta(CallToolParams{
+		Name: "tool",
+		Meta: &struct {
+			ProgressToken ProgressToken `json:"progressToken,omitempty"`
+		}{ProgressToken: "token"},
+	}, map[string]interface{}{"traceparent": "trace"})
+	require.NoError(t, err)
+	assert.Equal(t, "tool", params["name"])
+	assert.Equal(t, map[string]interface{}{"progressToken": "token", "traceparent": "trace"}, params["_meta"])
+
+	params, err = withParamsMeta(nil, map[string]interface{}{"traceparent": "trace"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"_meta": map[string]interface{}{"traceparent": "trace"}}, params)
+
+	_, err = withParamsMeta([]string{"not", "an", "object"}, map[string]interface{}{"traceparent": "trace"})
+	assert.Error(t, err)
+
+	rawResp := json.RawMessage(`{"jsonrpc":"2.0","id":1,"error":{"code":-32602,"message":"invalid"}}`)
+	errResp := ParseErrorResponse(&rawResp)
+	require.NotNil(t, errResp)
+	assert.Equal(t, ErrCodeInvalidParams, errResp.Error.Code)
+	rawResp = json.RawMessage(`{"tools":[]}`)
+	assert.Nil(t, ParseErrorResponse(&rawResp))
+}
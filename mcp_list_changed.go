@@ -0,0 +1,145 @@
+// Tencent is pleased to support the open source community by making trpc-mcp-go available.
+//
+// Copyright (C) 2025 Tencent.  All rights reserved.
+//
+// trpc-mcp-go is licensed under the Apache License Version 2.0.
+
+package mcp
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// defaultListChangedDebounce is the delay during which further changes of a list are coalesced into one notification
+const defaultListChangedDebounce = 100 * time.Millisecond
+
+// listRefreshTimeout bounds the requests re-fetching a list after a list_changed notification
+const listRefreshTimeout = 30 * time.Second
+
+// listChangedNotifier debounces list_changed notifications, so that a burst of registry changes
+// results in a single notification per list once the registry settles
+type listChangedNotifier struct {
+	delay  time.Duration
+	send   func(method string)
+	mu     sync.Mutex
+	timers map[string]*time.Timer
+}
+
+// newListChangedNotifier creates a notifier calling send with the notification method after changes settle
+func newListChangedNotifier(delay time.Duration, send func(method string)) *listChangedNotifier {
+	return &listChangedNotifier{
+		delay:  delay,
+		send:   send,
+		timers: make(map[string]*time.Timer),
+	}
+}
+
+// notify schedules a list_changed notification, postponing any pending notification of the same list.
+// It is a no-op on a nil notifier.
+func (n *listChangedNotifier) notify(method string) {
+	if n == nil {
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if timer, ok := n.timers[method]; ok {
+		timer.Reset(n.delay)
+		return
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(n.delay, func() {
+		n.mu.Lock()
+		if n.timers[method] == timer {
+			delete(n.timers, method)
+		}
+		n.mu.Unlock()
+
+		n.send(method)
+	})
+	n.timers[method] = timer
+}
+
+// WithToolListRefresh re-fetches all tools whenever the server sends notifications/tools/list_changed
+// and passes them to handler. The handler runs on its own goroutine.
+func WithToolListRefresh(handler func(tools []Tool)) ClientOption {
+	return func(c *Client) {
+		c.listRefresh.tools = handler
+	}
+}
+
+// WithPromptListRefresh re-fetches all prompts whenever the server sends notifications/prompts/list_changed
+// and passes them to handler. The handler runs on its own goroutine.
+func WithPromptListRefresh(handler func(prompts []Prompt)) ClientOption {
+	return func(c *Client) {
+		c.listRefresh.prompts = handler
+	}
+}
+
+// WithResourceListRefresh re-fetches all resources whenever the server sends
+// notifications/resources/list_changed and passes them to handler. The handler runs on its own goroutine.
+func WithResourceListRefresh(handler func(resources []Resource)) ClientOption {
+	return func(c *Client) {
+		c.listRefresh.resources = handler
+	}
+}
+
+// clientListRefresh holds the handlers receiving lists re-fetched after list_changed notifications
+type clientListRefresh struct {
+	tools     func(tools []Tool)
+	prompts   func(prompts []Prompt)
+	resources func(resources []Resource)
+}
+
+// enableListRefresh registers notification handlers re-fetching the lists the client opted in to
+func (c *Client) enableListRefresh() {
+	if handler := c.listRefresh.tools; handler != nil {
+		c.RegisterNotificationHandler(MethodNotificationsToolsListChanged, c.newListRefreshHandler(
+			func(ctx context.Context) error {
+				tools, err := c.ListAllTools(ctx)
+				if err == nil {
+					handler(tools)
+				}
+				return err
+			}))
+	}
+	if handler := c.listRefresh.prompts; handler != nil {
+		c.RegisterNotificationHandler(MethodNotificationsPromptsListChanged, c.newListRefreshHandler(
+			func(ctx context.Context) error {
+				prompts, err := c.ListAllPrompts(ctx)
+				if err == nil {
+					handler(prompts)
+				}
+				return err
+			}))
+	}
+	if handler := c.listRefresh.resources; handler != nil {
+		c.RegisterNotificationHandler(MethodNotificationsResourcesListChanged, c.newListRefreshHandler(
+			func(ctx context.Context) error {
+				resources, err := c.ListAllResources(ctx)
+				if err == nil {
+					handler(resources)
+				}
+				return err
+			}))
+	}
+}
+
+// newListRefreshHandler creates a notification handler running refresh in the background,
+// since the transport may need the goroutine delivering the notification to read the responses
+func (c *Client) newListRefreshHandler(refresh func(ctx context.Context) error) NotificationHandler {
+	return func(notification *JSONRPCNotification) error {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), listRefreshTimeout)
+			defer cancel()
+			if err := refresh(ctx); err != nil && c.logger != nil {
+				c.logger.Errorf("Failed to refresh list after %s: %v", notification.Method, err)
+			}
+		}()
+		return nil
+	}
+}
@@ -0,0 +1,100 @@
+// Tencent is pleased to support the open source community by making trpc-mcp-go available.
+//
+// Copyright (C) 2025 Tencent.  All rights reserved.
+//
+// trpc-mcp-go is licensed under the Apache License Version 2.0.
+
+package mcp
+
+import (
+	"context"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListChangedNotifier_Debounce(t *testing.T) {
+	var mu sync.Mutex
+	sent := make(map[string]int)
+	notifier := newListChangedNotifier(20*time.Millisecond, func(method string) {
+		mu.Lock()
+		defer mu.Unlock()
+		sent[method]++
+	})
+
+	// A burst of changes results in one notification per list
+	for i := 0; i < 10; i++ {
+		notifier.notify(MethodNotificationsToolsListChanged)
+	}
+	notifier.notify(MethodNotificationsPromptsListChanged)
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return sent[MethodNotificationsToolsListChanged] == 1 && sent[MethodNotificationsPromptsListChanged] == 1
+	}, time.Second, 5*time.Millisecond)
+
+	// Later changes are notified again
+	notifier.notify(MethodNotificationsToolsListChanged)
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return sent[MethodNotificationsToolsListChanged] == 2
+	}, time.Second, 5*time.Millisecond)
+
+	// A nil notifier is a no-op
+	var nilNotifier *listChangedNotifier
+	nilNotifier.notify(MethodNotificationsToolsListChanged)
+}
+
+func TestClient_ToolListRefresh(t *testing.T) {
+	server := NewServer("Test-Server", "1.0.0", WithServerPath("/mcp"))
+	toolHandler := func(ctx context.Context, req *CallToolRequest) (*CallToolResult, error) {
+		return NewTextResult(""), nil
+	}
+	server.RegisterTool(NewTool("static"), toolHandler)
+
+	httpServer := httptest.NewServer(server.HTTPHandler())
+	defer httpServer.Close()
+
+	refreshed := make(chan []Tool, 10)
+	client, err := NewClient(httpServer.URL+"/mcp", Implementation{Name: "Test-Client", Version: "1.0.0"},
+		WithClientGetSSEEnabled(true),
+		WithToolListRefresh(func(tools []Tool) {
+			refreshed <- tools
+		}))
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err = client.Initialize(ctx, &InitializeRequest{})
+	require.NoError(t, err)
+
+	// Changes are only delivered once the GET SSE connection is established, so keep registering until one arrives
+	require.Eventually(t, func() bool {
+		server.RegisterTool(NewTool("dynamic"), toolHandler)
+		select {
+		case tools := <-refreshed:
+			require.Len(t, tools, 2)
+			assert.Equal(t, "static", tools[0].Name)
+			assert.Equal(t, "dynamic", tools[1].Name)
+			return true
+		case <-time.After(500 * time.Millisecond):
+			return false
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, server.UnregisterTools("dynamic"))
+	select {
+	case tools := <-refreshed:
+		require.Len(t, tools, 1)
+		assert.Equal(t, "static", tools[0].Name)
+	case <-ctx.Done():
+		t.Fatal("tool list not refreshed after unregistering")
+	}
+}
@@ -0,0 +1,221 @@
+// Tencent is pleased to support the open source community by making trpc-mcp-go available.
+//
+// Copyright (C) 2025 Tencent.  All rights reserved.
+//
+// trpc-mcp-go is licensed under the Apache License Version 2.0.
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+)
+
+// LoggingLevel is the severity of a log message, the values map to the syslog severities of RFC 5424
+// Corresponds to the "LoggingLevel" definition in schema.json
+type LoggingLevel string
+
+// Logging levels, ordered by increasing severity
+const (
+	LoggingLevelDebug     LoggingLevel = "debug"
+	LoggingLevelInfo      LoggingLevel = "info"
+	LoggingLevelNotice    LoggingLevel = "notice"
+	LoggingLevelWarning   LoggingLevel = "warning"
+	LoggingLevelError     LoggingLevel = "error"
+	LoggingLevelCritical  LoggingLevel = "critical"
+	LoggingLevelAlert     LoggingLevel = "alert"
+	LoggingLevelEmergency LoggingLevel = "emergency"
+)
+
+// defaultLoggingLevel is the minimum level sent to clients that have not called logging/setLevel
+const defaultLoggingLevel = LoggingLevelInfo
+
+// loggingLevelSeverity orders the logging levels by severity
+var loggingLevelSeverity = map[LoggingLevel]int{
+	LoggingLevelDebug:     0,
+	LoggingLevelInfo:      1,
+	LoggingLevelNotice:    2,
+	LoggingLevelWarning:   3,
+	LoggingLevelError:     4,
+	LoggingLevelCritical:  5,
+	LoggingLevelAlert:     6,
+	LoggingLevelEmergency: 7,
+}
+
+// isValidLoggingLevel checks whether level is one of the standard logging levels
+func isValidLoggingLevel(level LoggingLevel) bool {
+	_, ok := loggingLevelSeverity[level]
+	return ok
+}
+
+// SetLevelRequest describes a request from the client to adjust the level of log messages it receives
+// Corresponds to the "SetLevelRequest" definition in schema.json
+type SetLevelRequest struct {
+	Request
+	Params struct {
+		// Level is the minimum level of log messages the client wants to receive
+		Level LoggingLevel `json:"level"`
+	} `json:"params"`
+}
+
+// LoggingMessageNotification describes a log message sent from the server to the client
+// Corresponds to the "LoggingMessageNotification" definition in schema.json
+type LoggingMessageNotification struct {
+	Notification
+	Params struct {
+		// Level is the severity of the message
+		Level LoggingLevel `json:"level"`
+
+		// Logger is an optional name of the logger issuing the message
+		Logger string `json:"logger,omitempty"`
+
+		// Data is the message, any JSON serializable value is allowed
+		Data interface{} `json:"data"`
+	} `json:"params"`
+}
+
+// NewLoggingMessageNotification creates a log message notification
+func NewLoggingMessageNotification(level LoggingLevel, logger string, data interface{}) *JSONRPCNotification {
+	params := map[string]interface{}{
+		"level": level,
+		"data":  data,
+	}
+	if logger != "" {
+		params["logger"] = logger
+	}
+	return newJSONRPCNotification(*NewNotification(NotificationMethodMessage, params))
+}
+
+// getSessionLoggingLevel gets the logging level the client set for the session
+func getSessionLoggingLevel(session Session) LoggingLevel {
+	if session == nil {
+		return defaultLoggingLevel
+	}
+	value, ok := session.GetData(sessionDataKeyLoggingLevel)
+	if !ok {
+		return defaultLoggingLevel
+	}
+	level, ok := value.(LoggingLevel)
+	if !ok {
+		return defaultLoggingLevel
+	}
+	return level
+}
+
+// handleSetLevel handles logging/setLevel requests by storing the level in the session
+func handleSetLevel(req *JSONRPCRequest, session Session) (JSONRPCMessage, error) {
+	paramsMap, ok := req.Params.(map[string]interface{})
+	if !ok {
+		return newJSONRPCErrorResponse(req.ID, ErrCodeInvalidParams, "invalid parameters", nil), nil
+	}
+	levelStr, _ := paramsMap["level"].(string)
+	level := LoggingLevel(levelStr)
+	if !isValidLoggingLevel(level) {
+		return newJSONRPCErrorResponse(req.ID, ErrCodeInvalidParams,
+			fmt.Sprintf("invalid logging level: %q", levelStr), nil), nil
+	}
+
+	if session != nil {
+		session.SetData(sessionDataKeyLoggingLevel, level)
+	}
+	return map[string]interface{}{}, nil
+}
+
+// ClientLogger sends log messages to the client of the current session as notifications/message.
+// Messages below the level the client set with logging/setLevel are dropped, until the client
+// sets a level only messages at info level and above are sent.
+// It implements Logger, Warn maps to the warning level and Fatal to the critical level without exiting.
+type ClientLogger struct {
+	ctx  context.Context
+	name string
+}
+
+// GetClientLogger returns a logger sending log messages to the client of the request being processed
+func GetClientLogger(ctx context.Context) *ClientLogger {
+	return &ClientLogger{ctx: ctx}
+}
+
+// WithName returns a copy of the logger that reports name as the logger of its messages
+func (l *ClientLogger) WithName(name string) *ClientLogger {
+	return &ClientLogger{ctx: l.ctx, name: name}
+}
+
+// Log sends a log message with the given level, data may be any JSON serializable value.
+// It returns nil without sending anything when the message is filtered out or there is no client to send to.
+func (l *ClientLogger) Log(level LoggingLevel, data interface{}) error {
+	if !isValidLoggingLevel(level) {
+		return fmt.Errorf("invalid logging level: %q", level)
+	}
+	sender, ok := GetNotificationSender(l.ctx)
+	if !ok {
+		return nil
+	}
+
+	session, _ := GetSessionFromContext(l.ctx)
+	if loggingLevelSeverity[level] < loggingLevelSeverity[getSessionLoggingLevel(session)] {
+		return nil
+	}
+
+	notification := NewLoggingMessageNotification(level, l.name, data)
+	return sender.SendNotification(&notification.Notification)
+}
+
+// Debug sends a debug level message
+func (l *ClientLogger) Debug(args ...interface{}) {
+	_ = l.Log(LoggingLevelDebug, fmt.Sprint(args...))
+}
+
+// Debugf sends a formatted debug level message
+func (l *ClientLogger) Debugf(format string, args ...interface{}) {
+	_ = l.Log(LoggingLevelDebug, fmt.Sprintf(format, args...))
+}
+
+// Info sends an info level message
+func (l *ClientLogger) Info(args ...interface{}) {
+	_ = l.Log(LoggingLevelInfo, fmt.Sprint(args...))
+}
+
+// Infof sends a formatted info level message
+func (l *ClientLogger) Infof(format string, args ...interface{}) {
+	_ = l.Log(LoggingLevelInfo, fmt.Sprintf(format, args...))
+}
+
+// Notice sends a notice level message
+func (l *ClientLogger) Notice(args ...interface{}) {
+	_ = l.Log(LoggingLevelNotice, fmt.Sprint(args...))
+}
+
+// Noticef sends a formatted notice level message
+func (l *ClientLogger) Noticef(format string, args ...interface{}) {
+	_ = l.Log(LoggingLevelNotice, fmt.Sprintf(format, args...))
+}
+
+// Warn sends a warning level message
+func (l *ClientLogger) Warn(args ...interface{}) {
+	_ = l.Log(LoggingLevelWarning, fmt.Sprint(args...))
+}
+
+// Warnf sends a formatted warning level message
+func (l *ClientLogger) Warnf(format string, args ...interface{}) {
+	_ = l.Log(LoggingLevelWarning, fmt.Sprintf(format, args...))
+}
+
+// Error sends an error level message
+func (l *ClientLogger) Error(args ...interface{}) {
+	_ = l.Log(LoggingLevelError, fmt.Sprint(args...))
+}
+
+// Errorf sends a formatted error level message
+func (l *ClientLogger) Errorf(format string, args ...interface{}) {
+	_ = l.Log(LoggingLevelError, fmt.Sprintf(format, args...))
+}
+
+// Fatal sends a critical level message, it does not exit the process
+func (l *ClientLogger) Fatal(args ...interface{}) {
+	_ = l.Log(LoggingLevelCritical, fmt.Sprint(args...))
+}
+
+// Fatalf sends a formatted critical level message, it does not exit the process
+func (l *ClientLogger) Fatalf(format string, args ...interface{}) {
+	_ = l.Log(LoggingLevelCritical, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,100 @@
+// Tencent is pleased to support the open source community by making trpc-mcp-go available.
+//
+// Copyright (C) 2025 Tencent.  All rights reserved.
+//
+// trpc-mcp-go is licensed under the Apache License Version 2.0.
+
+package mcp
+
+import (
+	"context"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleSetLevel(t *testing.T) {
+	session := newSession()
+	assert.Equal(t, defaultLoggingLevel, getSessionLoggingLevel(session))
+
+	// Unknown levels are rejected
+	resp, err := handleSetLevel(newJSONRPCRequest(1, MethodLoggingSetLevel, map[string]interface{}{
+		"level": "verbose",
+	}), session)
+	require.NoError(t, err)
+	errResp, ok := resp.(*JSONRPCError)
+	require.True(t, ok)
+	assert.Equal(t, ErrCodeInvalidParams, errResp.Error.Code)
+	assert.Equal(t, defaultLoggingLevel, getSessionLoggingLevel(session))
+
+	// The level is stored in the session
+	resp, err = handleSetLevel(newJSONRPCRequest(2, MethodLoggingSetLevel, map[string]interface{}{
+		"level": "warning",
+	}), session)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{}, resp)
+	assert.Equal(t, LoggingLevelWarning, getSessionLoggingLevel(session))
+}
+
+func TestSSEClient_LoggingLevel(t *testing.T) {
+	server := NewSSEServer("Test-SSE-Server", "1.0.0")
+	server.RegisterTool(NewTool("log"), func(ctx context.Context, req *CallToolRequest) (*CallToolResult, error) {
+		logger := GetClientLogger(ctx).WithName("tool")
+		logger.Debug("debug message")
+		logger.Infof("info %s", "message")
+		logger.Error("error message")
+		return NewTextResult("done"), nil
+	})
+
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	client, err := NewSSEClient(httpServer.URL+"/sse", Implementation{Name: "Test-Client", Version: "1.0.0"})
+	require.NoError(t, err)
+	defer client.Close()
+
+	var mu sync.Mutex
+	var levels []LoggingLevel
+	client.RegisterNotificationHandler(NotificationMethodMessage, func(n *JSONRPCNotification) error {
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, "tool", n.Params.AdditionalFields["logger"])
+		level, _ := n.Params.AdditionalFields["level"].(string)
+		levels = append(levels, LoggingLevel(level))
+		return nil
+	})
+	receivedLevels := func() []LoggingLevel {
+		mu.Lock()
+		defer mu.Unlock()
+		received := levels
+		levels = nil
+		return received
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	initResult, err := client.Initialize(ctx, &InitializeRequest{})
+	require.NoError(t, err)
+	assert.NotNil(t, initResult.Capabilities.Logging)
+
+	// Debug messages are dropped until the client lowers the level
+	_, err = client.CallTool(ctx, &CallToolRequest{Params: CallToolParams{Name: "log"}})
+	require.NoError(t, err)
+	assert.Equal(t, []LoggingLevel{LoggingLevelInfo, LoggingLevelError}, receivedLevels())
+
+	require.NoError(t, client.SetLoggingLevel(ctx, LoggingLevelError))
+	_, err = client.CallTool(ctx, &CallToolRequest{Params: CallToolParams{Name: "log"}})
+	require.NoError(t, err)
+	assert.Equal(t, []LoggingLevel{LoggingLevelError}, receivedLevels())
+
+	require.NoError(t, client.SetLoggingLevel(ctx, LoggingLevelDebug))
+	_, err = client.CallTool(ctx, &CallToolRequest{Params: CallToolParams{Name: "log"}})
+	require.NoError(t, err)
+	assert.Equal(t, []LoggingLevel{LoggingLevelDebug, LoggingLevelInfo, LoggingLevelError}, receivedLevels())
+
+	assert.Error(t, client.SetLoggingLevel(ctx, LoggingLevel("verbose")))
+}
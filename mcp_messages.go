@@ -26,6 +26,10 @@ type ClientCapabilities struct {
 	// Corresponds to schema: "sampling": {"description": "Present if the client supports sampling from an LLM."}
 	Sampling *SamplingCapability `json:"sampling,omitempty"`
 
+	// Elicitation indicates whether the client supports elicitation from the server
+	// Corresponds to schema: "elicitation": {"description": "Present if the client supports elicitation from the server."}
+	Elicitation *ElicitationCapability `json:"elicitation,omitempty"`
+
 	// Experimental indicates non-standard experimental capabilities that the client supports
 	// Corresponds to schema: "experimental": {"description": "Experimental, non-standard capabilities
 	// that the client supports."}
@@ -78,6 +82,11 @@ type SamplingCapability struct {
 	// Corresponds to schema.json definition, currently has no specific fields
 }
 
+// ElicitationCapability describes client elicitation capabilities
+type ElicitationCapability struct {
+	// Corresponds to schema.json definition, currently has no specific fields
+}
+
 // PromptsCapability describes server prompt capabilities
 type PromptsCapability struct {
 	// ListChanged indicates whether the server supports notifications for changes to the prompt list
@@ -174,15 +183,18 @@ const (
 	// Base protocol
 	MethodInitialize               = "initialize"
 	MethodNotificationsInitialized = "notifications/initialized"
+	MethodNotificationsCancelled   = "notifications/cancelled"
 
 	// Tool related
-	MethodToolsList = "tools/list"
-	MethodToolsCall = "tools/call"
+	MethodToolsList                     = "tools/list"
+	MethodToolsCall                     = "tools/call"
+	MethodNotificationsToolsListChanged = "notifications/tools/list_changed"
 
 	// Prompt related
-	MethodPromptsList        = "prompts/list"
-	MethodPromptsGet         = "prompts/get"
-	MethodCompletionComplete = "completion/complete"
+	MethodPromptsList                     = "prompts/list"
+	MethodPromptsGet                      = "prompts/get"
+	MethodCompletionComplete              = "completion/complete"
+	MethodNotificationsPromptsListChanged = "notifications/prompts/list_changed"
 
 	// Resource related
 	MethodResourcesList          = "resources/list"
@@ -191,6 +203,19 @@ const (
 	MethodResourcesSubscribe     = "resources/subscribe"
 	MethodResourcesUnsubscribe   = "resources/unsubscribe"
 
+	MethodNotificationsResourcesUpdated     = "notifications/resources/updated"
+	MethodNotificationsResourcesListChanged = "notifications/resources/list_changed"
+
+	// Sampling related
+	MethodSamplingCreateMessage = "sampling/createMessage"
+
+	// Elicitation related
+	MethodElicitationCreate = "elicitation/create"
+
+	// Roots related
+	MethodRootsList                     = "roots/list"
+	MethodNotificationsRootsListChanged = "notifications/roots/list_changed"
+
 	// Utilities
 	MethodLoggingSetLevel = "logging/setLevel"
 	MethodPing            = "ping"
@@ -200,10 +225,12 @@ const (
 const (
 	ProtocolVersion_2024_11_05 = "2024-11-05"
 	ProtocolVersion_2025_03_26 = "2025-03-26"
+	ProtocolVersion_2025_06_18 = "2025-06-18"
 )
 
 // List of supported protocol versions, ordered by priority
 var SupportedProtocolVersions = []string{
+	ProtocolVersion_2025_06_18,
 	ProtocolVersion_2025_03_26,
 	ProtocolVersion_2024_11_05,
 }
@@ -218,6 +245,23 @@ func IsProtocolVersionSupported(version string) bool {
 	return false
 }
 
+// isProtocolVersionAtLeast checks if a protocol version is the same as or newer than the target version.
+// Protocol versions are dates in YYYY-MM-DD format, so they can be compared lexically.
+func isProtocolVersionAtLeast(version, target string) bool {
+	return version >= target
+}
+
+// protocolVersionSupportsBatching checks if JSON-RPC batching is allowed by a protocol version.
+// Batching was introduced in 2025-03-26 and removed again in 2025-06-18.
+func protocolVersionSupportsBatching(version string) bool {
+	return !isProtocolVersionAtLeast(version, ProtocolVersion_2025_06_18)
+}
+
+// protocolVersionRequiresHeader checks if HTTP requests must carry the MCP-Protocol-Version header.
+func protocolVersionRequiresHeader(version string) bool {
+	return isProtocolVersionAtLeast(version, ProtocolVersion_2025_06_18)
+}
+
 // Helper functions
 
 // NewInitializeRequest creates an initialization request
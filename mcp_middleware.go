@@ -0,0 +1,91 @@
+// Tencent is pleased to support the open source community by making trpc-mcp-go available.
+//
+// Copyright (C) 2025 Tencent.  All rights reserved.
+//
+// trpc-mcp-go is licensed under the Apache License Version 2.0.
+
+package mcp
+
+import (
+	"context"
+)
+
+// ServerRequest describes a request or notification received by the server
+type ServerRequest struct {
+	// Method name
+	Method string
+
+	// Request ID, nil for notifications
+	ID RequestId
+
+	// Method parameters, NotificationParams for notifications.
+	// Middlewares may replace them with a value of the same type before calling the next handler.
+	Params interface{}
+
+	// Session of the client, nil if the transport has no session
+	Session Session
+
+	// Whether the message is a notification, which has no response
+	Notification bool
+
+	// Received request or notification
+	request      *JSONRPCRequest
+	notification *JSONRPCNotification
+}
+
+// NewError creates an error response to the request, returned by middlewares to reject it
+func (r *ServerRequest) NewError(code int, message string, data interface{}) *JSONRPCError {
+	return newJSONRPCErrorResponse(r.ID, code, message, data)
+}
+
+// HandlerFunc handles a request or notification received by the server.
+// It returns the result of a request, which is a *JSONRPCError if the request failed,
+// and nil for notifications.
+type HandlerFunc func(ctx context.Context, req *ServerRequest) (JSONRPCMessage, error)
+
+// ServerMiddleware wraps the handling of every request and notification received by the server,
+// e.g. for authentication, auditing or metrics. A middleware can inspect the request and the
+// result of next, or short-circuit the request by returning an error response created by
+// ServerRequest.NewError without calling next.
+//
+// Example:
+//
+//	func auditMiddleware(next mcp.HandlerFunc) mcp.HandlerFunc {
+//	    return func(ctx context.Context, req *mcp.ServerRequest) (mcp.JSONRPCMessage, error) {
+//	        start := time.Now()
+//	        result, err := next(ctx, req)
+//	        log.Printf("%s took %v", req.Method, time.Since(start))
+//	        return result, err
+//	    }
+//	}
+type ServerMiddleware func(next HandlerFunc) HandlerFunc
+
+// newRequestServerRequest creates the description of a request for middlewares
+func newRequestServerRequest(req *JSONRPCRequest, session Session) *ServerRequest {
+	return &ServerRequest{
+		Method:  req.Method,
+		ID:      req.ID,
+		Params:  req.Params,
+		Session: session,
+		request: req,
+	}
+}
+
+// newNotificationServerRequest creates the description of a notification for middlewares
+func newNotificationServerRequest(notification *JSONRPCNotification, session Session) *ServerRequest {
+	return &ServerRequest{
+		Method:       notification.Method,
+		Params:       notification.Params,
+		Session:      session,
+		Notification: true,
+		notification: notification,
+	}
+}
+
+// chainMiddlewares wraps handler with middlewares, the first middleware being the outermost
+func chainMiddlewares(handler HandlerFunc, middlewares []ServerMiddleware) HandlerFunc {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
@@ -0,0 +1,162 @@
+// Tencent is pleased to support the open source community by making trpc-mcp-go available.
+//
+// Copyright (C) 2025 Tencent.  All rights reserved.
+//
+// trpc-mcp-go is licensed under the Apache License Version 2.0.
+
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMCPHandler_Middlewares(t *testing.T) {
+	var calls []string
+	recorder := func(name string) ServerMiddleware {
+		return func(next HandlerFunc) HandlerFunc {
+			return func(ctx context.Context, req *ServerRequest) (JSONRPCMessage, error) {
+				calls = append(calls, name+" before "+req.Method)
+				result, err := next(ctx, req)
+				calls = append(calls, name+" after "+req.Method)
+				return result, err
+			}
+		}
+	}
+	// Rejects calls of the admin tool and overrides the arguments of the echo tool
+	guard := func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, req *ServerRequest) (JSONRPCMessage, error) {
+			if req.Method != MethodToolsCall {
+				return next(ctx, req)
+			}
+			params := req.Params.(map[string]interface{})
+			if params["name"] == "admin" {
+				return req.NewError(ErrCodeInvalidRequest, "forbidden", nil), nil
+			}
+			req.Params = map[string]interface{}{
+				"name":      params["name"],
+				"arguments": map[string]interface{}{"text": "overridden"},
+			}
+			return next(ctx, req)
+		}
+	}
+
+	toolManager := newToolManager()
+	toolCalls := 0
+	toolHandler := func(ctx context.Context, req *CallToolRequest) (*CallToolResult, error) {
+		toolCalls++
+		return NewTextResult(req.Params.Arguments["text"].(string)), nil
+	}
+	toolManager.registerTool(NewTool("echo", WithString("text")), toolHandler)
+	toolManager.registerTool(NewTool("admin", WithString("text")), toolHandler)
+
+	handler := newMCPHandler(
+		withToolManager(toolManager),
+		withMiddlewares(recorder("outer"), recorder("inner"), guard),
+	)
+	ctx := context.Background()
+	session := newSession()
+
+	// Middlewares wrap the request in order and can replace its params
+	result, err := handler.handleRequest(ctx, newJSONRPCRequest(1, MethodToolsCall, map[string]interface{}{
+		"name":      "echo",
+		"arguments": map[string]interface{}{"text": "hello"},
+	}), session)
+	require.NoError(t, err)
+	callResult, ok := result.(*CallToolResult)
+	require.True(t, ok, "Expected *CallToolResult but got %T", result)
+	assert.Equal(t, "overridden", callResult.Content[0].(TextContent).Text)
+	assert.Equal(t, []string{
+		"outer before tools/call", "inner before tools/call", "inner after tools/call", "outer after tools/call",
+	}, calls)
+
+	// A middleware can short-circuit the request with an error response
+	result, err = handler.handleRequest(ctx, newJSONRPCRequest(2, MethodToolsCall, map[string]interface{}{
+		"name": "admin",
+	}), session)
+	require.NoError(t, err)
+	errorResp, ok := result.(*JSONRPCError)
+	require.True(t, ok, "Expected *JSONRPCError but got %T", result)
+	assert.Equal(t, 2, errorResp.ID)
+	assert.Equal(t, ErrCodeInvalidRequest, errorResp.Error.Code)
+	assert.Equal(t, 1, toolCalls)
+
+	// Notifications go through the middlewares as well
+	calls = nil
+	err = handler.handleNotification(ctx, NewJSONRPCNotificationFromMap(MethodNotificationsRootsListChanged, nil), session)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"outer before notifications/roots/list_changed", "inner before notifications/roots/list_changed",
+		"inner after notifications/roots/list_changed", "outer after notifications/roots/list_changed",
+	}, calls)
+}
+
+func TestStdioServer_Middleware(t *testing.T) {
+	var methods []string
+	server := NewStdioServer("test-server", "1.0.0", WithStdioMiddleware(
+		func(next HandlerFunc) HandlerFunc {
+			return func(ctx context.Context, req *ServerRequest) (JSONRPCMessage, error) {
+				methods = append(methods, req.Method)
+				if req.Method == MethodPing {
+					return req.NewError(ErrCodeInternal, "quota exceeded", nil), nil
+				}
+				return next(ctx, req)
+			}
+		},
+	))
+
+	ctx := context.WithValue(context.Background(), sessionKey{}, server.session)
+	rawRequest, err := json.Marshal(newJSONRPCRequest(1, MethodToolsList, nil))
+	require.NoError(t, err)
+	result, err := server.internal.HandleRequest(ctx, rawRequest)
+	require.NoError(t, err)
+	_, ok := result.(*JSONRPCResponse)
+	assert.True(t, ok, "Expected *JSONRPCResponse but got %T", result)
+
+	rawRequest, err = json.Marshal(newJSONRPCRequest(2, MethodPing, nil))
+	require.NoError(t, err)
+	result, err = server.internal.HandleRequest(ctx, rawRequest)
+	require.NoError(t, err)
+	errorResp, ok := result.(*JSONRPCError)
+	require.True(t, ok, "Expected *JSONRPCError but got %T", result)
+	assert.Equal(t, "quota exceeded", errorResp.Error.Message)
+
+	assert.Equal(t, []string{MethodToolsList, MethodPing}, methods)
+}
+
+func TestStdioServer_MiddlewareRejectsInitialized(t *testing.T) {
+	reject := true
+	server := NewStdioServer("test-server", "1.0.0", WithStdioMiddleware(
+		func(next HandlerFunc) HandlerFunc {
+			return func(ctx context.Context, req *ServerRequest) (JSONRPCMessage, error) {
+				if reject && req.Method == MethodNotificationsInitialized {
+					return req.NewError(ErrCodeInvalidRequest, "not allowed", nil), nil
+				}
+				return next(ctx, req)
+			}
+		},
+	))
+	ctx := context.WithValue(context.Background(), sessionKey{}, server.session)
+	rawRequest, err := json.Marshal(newJSONRPCRequest(1, MethodInitialize, map[string]interface{}{
+		"protocolVersion": ProtocolVersion_2025_03_26,
+		"clientInfo":      map[string]interface{}{"name": "test-client", "version": "1.0.0"},
+	}))
+	require.NoError(t, err)
+	_, err = server.internal.HandleRequest(ctx, rawRequest)
+	require.NoError(t, err)
+	rawNotification, err := json.Marshal(NewJSONRPCNotificationFromMap(MethodNotificationsInitialized, nil))
+	require.NoError(t, err)
+
+	// The session is not initialized when a middleware rejects the notification
+	err = server.internal.HandleNotification(ctx, rawNotification)
+	assert.EqualError(t, err, "notification notifications/initialized rejected: not allowed")
+	assert.False(t, server.session.Initialized())
+
+	reject = false
+	require.NoError(t, server.internal.HandleNotification(ctx, rawNotification))
+	assert.True(t, server.session.Initialized())
+}
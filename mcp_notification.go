@@ -30,8 +30,10 @@ type notificationSender interface {
 	// SendLogMessage sends a log message notification
 	SendLogMessage(level string, message string) error
 
-	// SendProgress sends a progress update notification
-	SendProgress(progress float64, message string) error
+	// SendProgress sends a progress update notification.
+	// It only sends a notification when the request being processed carries a progress token,
+	// a total of zero means the total amount of work is unknown.
+	SendProgress(progress, total float64, message string) error
 
 	// SendCustomNotification sends a custom notification
 	SendCustomNotification(method string, params map[string]interface{}) error
@@ -0,0 +1,86 @@
+// Tencent is pleased to support the open source community by making trpc-mcp-go available.
+//
+// Copyright (C) 2025 Tencent.  All rights reserved.
+//
+// trpc-mcp-go is licensed under the Apache License Version 2.0.
+
+package mcp
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+)
+
+// encodeCursor creates an opaque cursor pointing after the item with the given registration sequence number
+func encodeCursor(seq uint64) Cursor {
+	return Cursor(base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(seq, 10))))
+}
+
+// decodeCursor extracts the registration sequence number from a cursor
+func decodeCursor(cursor Cursor) (uint64, error) {
+	data, err := base64.RawURLEncoding.DecodeString(string(cursor))
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor: %q", cursor)
+	}
+	seq, err := strconv.ParseUint(string(data), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor: %q", cursor)
+	}
+	return seq, nil
+}
+
+// getCursorParam gets the cursor of a paginated list request, or an empty cursor for the first page
+func getCursorParam(req *JSONRPCRequest) Cursor {
+	paramsMap, ok := req.Params.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	cursor, _ := paramsMap["cursor"].(string)
+	return Cursor(cursor)
+}
+
+// paginate selects the page following cursor among items listed in registration order,
+// seqs holding their registration sequence numbers. It returns the bounds of the page and
+// the cursor of the next page, which is empty on the last page. A page size of zero disables pagination.
+// Cursors stay valid when items are registered or removed, since they refer to sequence numbers rather than positions.
+func paginate(seqs []uint64, cursor Cursor, pageSize int) (start, end int, nextCursor Cursor, err error) {
+	if cursor != "" {
+		after, err := decodeCursor(cursor)
+		if err != nil {
+			return 0, 0, "", err
+		}
+		for start < len(seqs) && seqs[start] <= after {
+			start++
+		}
+	}
+
+	end = len(seqs)
+	if pageSize > 0 && end-start > pageSize {
+		end = start + pageSize
+		nextCursor = encodeCursor(seqs[end-1])
+	}
+	return start, end, nextCursor, nil
+}
+
+// listAllPages calls listPage with the cursor of each page, starting from the first page,
+// until listPage returns an empty next cursor
+func listAllPages(listPage func(cursor Cursor) (Cursor, error)) error {
+	var cursor Cursor
+	seen := make(map[Cursor]bool)
+	for {
+		nextCursor, err := listPage(cursor)
+		if err != nil {
+			return err
+		}
+		if nextCursor == "" {
+			return nil
+		}
+		// Guard against servers returning the same cursor forever
+		if seen[nextCursor] {
+			return fmt.Errorf("pagination cursor %q repeated", nextCursor)
+		}
+		seen[nextCursor] = true
+		cursor = nextCursor
+	}
+}
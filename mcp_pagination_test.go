@@ -0,0 +1,159 @@
+// Tencent is pleased to support the open source community by making trpc-mcp-go available.
+//
+// Copyright (C) 2025 Tencent.  All rights reserved.
+//
+// trpc-mcp-go is licensed under the Apache License Version 2.0.
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaginate(t *testing.T) {
+	seqs := []uint64{1, 2, 4, 5, 7}
+
+	// A page size of zero returns everything
+	start, end, next, err := paginate(seqs, "", 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, start)
+	assert.Equal(t, 5, end)
+	assert.Empty(t, next)
+
+	start, end, next, err = paginate(seqs, "", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2}, seqs[start:end])
+	assert.NotEmpty(t, next)
+
+	start, end, next, err = paginate(seqs, next, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{4, 5}, seqs[start:end])
+
+	// The cursor stays valid after the item it points to is removed
+	start, end, next, err = paginate([]uint64{1, 2, 4, 7}, next, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{7}, []uint64{1, 2, 4, 7}[start:end])
+	assert.Empty(t, next)
+
+	_, _, _, err = paginate(seqs, "not a cursor", 2)
+	assert.Error(t, err)
+}
+
+func TestToolManager_PaginateFilteredTools(t *testing.T) {
+	manager := newToolManager().withPageSize(2)
+	var names []string
+	for i := 0; i < 5; i++ {
+		name := fmt.Sprintf("tool-%d", i)
+		names = append(names, name)
+		manager.registerTool(NewTool(name), func(ctx context.Context, req *CallToolRequest) (*CallToolResult, error) {
+			return NewTextResult(""), nil
+		})
+	}
+	// The filter reverses the tools, drops one, and adds a copy and an unregistered tool
+	manager.withToolListFilter(func(ctx context.Context, tools []*Tool) []*Tool {
+		var filtered []*Tool
+		for i := len(tools) - 1; i >= 0; i-- {
+			if tools[i].Name != "tool-2" {
+				filtered = append(filtered, tools[i])
+			}
+		}
+		copied := *tools[0]
+		return append(filtered, &copied, NewTool("unregistered"))
+	})
+
+	var listed []string
+	var cursor interface{}
+	for page := 0; page < 5; page++ {
+		result, err := manager.handleListTools(context.Background(),
+			newJSONRPCRequest(page, MethodToolsList, map[string]interface{}{"cursor": cursor}), nil)
+		require.NoError(t, err)
+		listResult := result.(ListToolsResult)
+		for _, tool := range listResult.Tools {
+			listed = append(listed, tool.Name)
+		}
+		if listResult.NextCursor == "" {
+			break
+		}
+		cursor = string(listResult.NextCursor)
+	}
+	assert.Equal(t, []string{"tool-0", "tool-1", "tool-3", "tool-4"}, listed)
+}
+
+func TestClient_ListAll(t *testing.T) {
+	server := NewServer("Test-Server", "1.0.0", WithServerPath("/mcp"), WithPageSize(2))
+	var names []string
+	for i := 0; i < 5; i++ {
+		name := fmt.Sprintf("item-%d", i)
+		names = append(names, name)
+		server.RegisterTool(NewTool(name), func(ctx context.Context, req *CallToolRequest) (*CallToolResult, error) {
+			return NewTextResult(""), nil
+		})
+		server.RegisterPrompt(&Prompt{Name: name}, nil)
+		server.RegisterResource(&Resource{URI: "file:///" + name, Name: name}, nil)
+		server.RegisterResourceTemplate(NewResourceTemplate("file:///"+name+"/{id}", name), nil)
+	}
+
+	httpServer := httptest.NewServer(server.HTTPHandler())
+	defer httpServer.Close()
+
+	client, err := NewClient(httpServer.URL+"/mcp", Implementation{Name: "Test-Client", Version: "1.0.0"})
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err = client.Initialize(ctx, &InitializeRequest{})
+	require.NoError(t, err)
+
+	// A single page is limited to the page size
+	page, err := client.ListTools(ctx, &ListToolsRequest{})
+	require.NoError(t, err)
+	assert.Len(t, page.Tools, 2)
+	assert.NotEmpty(t, page.NextCursor)
+
+	// Invalid cursors are rejected
+	req := &ListToolsRequest{}
+	req.Params.Cursor = "invalid"
+	_, err = client.ListTools(ctx, req)
+	assert.Error(t, err)
+
+	// All pages are listed in registration order
+	tools, err := client.ListAllTools(ctx)
+	require.NoError(t, err)
+	var toolNames []string
+	for _, tool := range tools {
+		toolNames = append(toolNames, tool.Name)
+	}
+	assert.Equal(t, names, toolNames)
+
+	prompts, err := client.ListAllPrompts(ctx)
+	require.NoError(t, err)
+	var promptNames []string
+	for _, prompt := range prompts {
+		promptNames = append(promptNames, prompt.Name)
+	}
+	assert.Equal(t, names, promptNames)
+
+	resources, err := client.ListAllResources(ctx)
+	require.NoError(t, err)
+	var resourceNames []string
+	for _, resource := range resources {
+		resourceNames = append(resourceNames, resource.Name)
+	}
+	assert.Equal(t, names, resourceNames)
+
+	templates, err := client.ListAllResourceTemplates(ctx)
+	require.NoError(t, err)
+	var templateNames []string
+	for _, template := range templates {
+		templateNames = append(templateNames, template.Name)
+	}
+	assert.Equal(t, names, templateNames)
+}
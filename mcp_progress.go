@@ -0,0 +1,175 @@
+// Tencent is pleased to support the open source community by making trpc-mcp-go available.
+//
+// Copyright (C) 2025 Tencent.  All rights reserved.
+//
+// trpc-mcp-go is licensed under the Apache License Version 2.0.
+
+package mcp
+
+import (
+	"sync"
+)
+
+// ProgressNotification reports the progress of a long-running request
+// Corresponds to the "ProgressNotification" definition in schema.json
+type ProgressNotification struct {
+	Notification
+	Params struct {
+		// ProgressToken is the token given in the _meta of the original request
+		ProgressToken ProgressToken `json:"progressToken"`
+
+		// Progress is the progress so far, it increases with each notification
+		Progress float64 `json:"progress"`
+
+		// Total is the total amount of work, zero if unknown
+		Total float64 `json:"total,omitempty"`
+
+		// Message is an optional description of the current progress
+		Message string `json:"message,omitempty"`
+	} `json:"params"`
+}
+
+// NewProgressNotification creates a progress notification for the request identified by progressToken.
+// A total of zero means the total amount of work is unknown.
+func NewProgressNotification(progressToken ProgressToken, progress, total float64, message string) *JSONRPCNotification {
+	params := map[string]interface{}{
+		"progressToken": progressToken,
+		"progress":      progress,
+	}
+	if total > 0 {
+		params["total"] = total
+	}
+	if message != "" {
+		params["message"] = message
+	}
+	return newJSONRPCNotification(*NewNotification(NotificationMethodProgress, params))
+}
+
+// parseProgressNotification converts a generic notification into a progress notification
+func parseProgressNotification(notification *JSONRPCNotification) (*ProgressNotification, bool) {
+	if notification == nil || notification.Method != NotificationMethodProgress {
+		return nil, false
+	}
+	fields := notification.Params.AdditionalFields
+	token, ok := fields["progressToken"]
+	if !ok || token == nil {
+		return nil, false
+	}
+
+	progress := &ProgressNotification{Notification: notification.Notification}
+	progress.Params.ProgressToken = token
+	progress.Params.Progress, _ = fields["progress"].(float64)
+	progress.Params.Total, _ = fields["total"].(float64)
+	progress.Params.Message, _ = fields["message"].(string)
+	return progress, true
+}
+
+// progressNotificationSender decorates a notification sender with the progress token of the request being processed
+type progressNotificationSender struct {
+	notificationSender
+	progressToken ProgressToken
+}
+
+// SendProgress sends a progress notification tied to the caller's progress token
+func (s *progressNotificationSender) SendProgress(progress, total float64, message string) error {
+	notification := NewProgressNotification(s.progressToken, progress, total, message)
+	return s.SendNotification(&notification.Notification)
+}
+
+// ProgressHandler handles progress notifications of a single request
+type ProgressHandler func(notification *ProgressNotification)
+
+// CallToolOption configures a single tools/call request, see CallToolWithOptions
+type CallToolOption func(*callToolOptions)
+
+// callToolOptions holds the per-call options of tools/call
+type callToolOptions struct {
+	progressHandler ProgressHandler
+}
+
+// WithProgressHandler requests progress notifications for the call and delivers them to handler.
+// The request's progress token is generated unless one is already set in the params' _meta.
+func WithProgressHandler(handler ProgressHandler) CallToolOption {
+	return func(o *callToolOptions) {
+		o.progressHandler = handler
+	}
+}
+
+// newCallToolOptions applies the per-call options
+func newCallToolOptions(options []CallToolOption) *callToolOptions {
+	o := &callToolOptions{}
+	for _, option := range options {
+		option(o)
+	}
+	return o
+}
+
+// withProgressToken returns a copy of params carrying a progress token, along with the token.
+// An existing token is kept, otherwise defaultToken is used.
+func withProgressToken(params CallToolParams, defaultToken ProgressToken) (CallToolParams, ProgressToken) {
+	if params.Meta != nil && params.Meta.ProgressToken != nil {
+		return params, params.Meta.ProgressToken
+	}
+	params.Meta = &struct {
+		ProgressToken ProgressToken `json:"progressToken,omitempty"`
+	}{
+		ProgressToken: defaultToken,
+	}
+	return params, defaultToken
+}
+
+// progressHandlerTransport is implemented by client transports that route progress notifications to per-request handlers
+type progressHandlerTransport interface {
+	// registerProgressHandler registers a handler for a progress token and returns a function removing it
+	registerProgressHandler(token ProgressToken, handler ProgressHandler) func()
+}
+
+// clientProgressHandlers dispatches progress notifications to the handlers of in-flight requests
+type clientProgressHandlers struct {
+	// Handlers keyed by normalized progress token
+	handlers map[string]ProgressHandler
+
+	// Mutex for handlers map
+	mu sync.RWMutex
+}
+
+// newClientProgressHandlers creates an empty progress handler registry
+func newClientProgressHandlers() *clientProgressHandlers {
+	return &clientProgressHandlers{
+		handlers: make(map[string]ProgressHandler),
+	}
+}
+
+// register registers a handler for a progress token and returns a function removing it
+func (h *clientProgressHandlers) register(token ProgressToken, handler ProgressHandler) func() {
+	key := requestIDKey(token)
+
+	h.mu.Lock()
+	h.handlers[key] = handler
+	h.mu.Unlock()
+
+	return func() {
+		h.mu.Lock()
+		delete(h.handlers, key)
+		h.mu.Unlock()
+	}
+}
+
+// handle delivers a progress notification to the handler registered for its token.
+// It reports whether a handler was found.
+func (h *clientProgressHandlers) handle(notification *JSONRPCNotification) bool {
+	progress, ok := parseProgressNotification(notification)
+	if !ok {
+		return false
+	}
+
+	h.mu.RLock()
+	handler, ok := h.handlers[requestIDKey(progress.Params.ProgressToken)]
+	h.mu.RUnlock()
+
+	if !ok || handler == nil {
+		return false
+	}
+	handler(progress)
+	return true
+}
@@ -0,0 +1,109 @@
+// Tencent is pleased to support the open source community by making trpc-mcp-go available.
+//
+// Copyright (C) 2025 Tencent.  All rights reserved.
+//
+// trpc-mcp-go is licensed under the Apache License Version 2.0.
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientProgressHandlers(t *testing.T) {
+	handlers := newClientProgressHandlers()
+
+	var received []*ProgressNotification
+	unregister := handlers.register(int64(7), func(n *ProgressNotification) {
+		received = append(received, n)
+	})
+
+	// Numeric tokens match regardless of their JSON decoding
+	assert.True(t, handlers.handle(NewProgressNotification(float64(7), 1, 4, "step 1")))
+	assert.False(t, handlers.handle(NewProgressNotification("other", 1, 4, "step 1")))
+	require.Len(t, received, 1)
+	assert.Equal(t, 1.0, received[0].Params.Progress)
+	assert.Equal(t, 4.0, received[0].Params.Total)
+	assert.Equal(t, "step 1", received[0].Params.Message)
+
+	// Removed handlers no longer receive notifications
+	unregister()
+	assert.False(t, handlers.handle(NewProgressNotification(7, 2, 4, "")))
+	assert.Len(t, received, 1)
+}
+
+func TestClient_CallToolProgress(t *testing.T) {
+	server := NewServer("Test-Server", "1.0.0", WithServerPath("/mcp"), WithPostSSEEnabled(true))
+	var receivedToken ProgressToken
+	server.RegisterTool(NewTool("work"), func(ctx context.Context, req *CallToolRequest) (*CallToolResult, error) {
+		if req.Params.Meta != nil {
+			receivedToken = req.Params.Meta.ProgressToken
+		}
+		sender, ok := GetNotificationSender(ctx)
+		if !ok {
+			return nil, fmt.Errorf("no notification sender")
+		}
+		for i := 1; i <= 3; i++ {
+			if err := sender.SendProgress(float64(i), 3, fmt.Sprintf("step %d", i)); err != nil {
+				return nil, err
+			}
+		}
+		return NewTextResult("done"), nil
+	})
+
+	httpServer := httptest.NewServer(server.HTTPHandler())
+	defer httpServer.Close()
+
+	client, err := NewClient(httpServer.URL+"/mcp", Implementation{Name: "Test-Client", Version: "1.0.0"})
+	require.NoError(t, err)
+	defer client.Close()
+
+	// Progress notifications without a per-call handler reach the global handler
+	var mu sync.Mutex
+	var globalCount int
+	client.RegisterNotificationHandler(NotificationMethodProgress, func(*JSONRPCNotification) error {
+		mu.Lock()
+		defer mu.Unlock()
+		globalCount++
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err = client.Initialize(ctx, &InitializeRequest{})
+	require.NoError(t, err)
+
+	// Without a progress handler the request carries no token and no progress is sent
+	_, err = client.CallTool(ctx, &CallToolRequest{Params: CallToolParams{Name: "work"}})
+	require.NoError(t, err)
+	assert.Nil(t, receivedToken)
+
+	var progress []*ProgressNotification
+	result, err := client.CallToolWithOptions(ctx, &CallToolRequest{Params: CallToolParams{Name: "work"}},
+		WithProgressHandler(func(n *ProgressNotification) {
+			progress = append(progress, n)
+		}))
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.Content[0].(TextContent).Text)
+
+	require.NotNil(t, receivedToken)
+	require.Len(t, progress, 3)
+	for i, n := range progress {
+		assert.Equal(t, requestIDKey(receivedToken), requestIDKey(n.Params.ProgressToken))
+		assert.Equal(t, float64(i+1), n.Params.Progress)
+		assert.Equal(t, 3.0, n.Params.Total)
+		assert.Equal(t, fmt.Sprintf("step %d", i+1), n.Params.Message)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 3, globalCount)
+}
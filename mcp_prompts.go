@@ -15,10 +15,19 @@ import (
 // promptHandler defines the function type for handling prompt requests
 type promptHandler func(ctx context.Context, req *GetPromptRequest) (*GetPromptResult, error)
 
+// ServerPrompt pairs a prompt with its handler, for replacing the registered prompts at once
+type ServerPrompt struct {
+	Prompt  *Prompt
+	Handler promptHandler
+}
+
 // registeredPrompt combines a Prompt with its handler function
 type registeredPrompt struct {
 	Prompt  *Prompt
 	Handler promptHandler
+
+	// Registration sequence number, used for pagination cursors
+	seq uint64
 }
 
 // ListPromptsRequest describes a request to list prompts.
@@ -59,6 +68,10 @@ type Prompt struct {
 	// Corresponds to schema: "name": {"description": "The name of the prompt or prompt template."}
 	Name string `json:"name"`
 
+	// Title is an optional human-readable display name of the prompt
+	// Corresponds to schema: "title": {"description": "Intended for UI and end-user contexts."}
+	Title string `json:"title,omitempty"`
+
 	// Description is an optional description of the prompt
 	// Corresponds to schema: "description": {"description": "An optional description of what this prompt provides"}
 	Description string `json:"description,omitempty"`
@@ -78,6 +91,9 @@ type PromptArgument struct {
 
 	// Whether the parameter is required
 	Required bool `json:"required,omitempty"`
+
+	// Completion suggests values for the parameter in completion/complete requests (optional)
+	Completion CompletionProvider `json:"-"`
 }
 
 // PromptMessage describes the message returned by the prompt
@@ -110,17 +126,9 @@ func (pm *PromptMessage) UnmarshalJSON(data []byte) error {
 			return nil
 		}
 
-		// Parse the content as a map for further processing
-		var contentMap map[string]interface{}
-		if err := json.Unmarshal(temp.Content, &contentMap); err != nil {
-			return fmt.Errorf("failed to unmarshal content field: %w", err)
-		}
-
-		// If not directly accessible, adjust the call (e.g., qualify with package if needed).
-		// We assume it can be called directly in same package or as mcp.parseContent if public.
-		concreteContent, err := parseContent(contentMap) // This function is in mcp/tools.go (mcp.parseContent)
+		concreteContent, err := unmarshalContent(temp.Content)
 		if err != nil {
-			return fmt.Errorf("failed to parse concrete content using parseContent: %w", err)
+			return fmt.Errorf("failed to parse prompt message content: %w", err)
 		}
 		pm.Content = concreteContent
 	} else {
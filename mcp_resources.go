@@ -23,12 +23,18 @@ type resourceTemplateHandler func(ctx context.Context, req *ReadResourceRequest)
 type registeredResource struct {
 	Resource *Resource
 	Handler  resourceHandler
+
+	// Registration sequence number, used for pagination cursors
+	seq uint64
 }
 
 // registerResourceTemplate combines a ResourceTemplate with its handler function.
 type registerResourceTemplate struct {
 	resourceTemplate *ResourceTemplate
 	Handler          resourceTemplateHandler
+
+	// Registration sequence number, used for pagination cursors
+	seq uint64
 }
 
 // Resource represents a known resource that the server can read.
@@ -36,6 +42,9 @@ type Resource struct {
 	// Resource name
 	Name string `json:"name"`
 
+	// Human-readable display name (optional, since 2025-06-18)
+	Title string `json:"title,omitempty"`
+
 	// Resource URI
 	URI string `json:"uri"`
 
@@ -86,6 +95,17 @@ type ListResourcesResult struct {
 	Resources []Resource `json:"resources"`
 }
 
+// ListResourceTemplatesRequest describes a request to list resource templates.
+type ListResourceTemplatesRequest struct {
+	PaginatedRequest
+}
+
+// ListResourceTemplatesResult describes a result of listing resource templates.
+type ListResourceTemplatesResult struct {
+	PaginatedResult
+	ResourceTemplates []ResourceTemplate `json:"resourceTemplates"`
+}
+
 // ReadResourceRequest describes a request to read a resource.
 type ReadResourceRequest struct {
 	Request
@@ -130,6 +150,9 @@ type ResourceTemplate struct {
 	// Template name
 	Name string `json:"name"`
 
+	// Human-readable display name (optional, since 2025-06-18)
+	Title string `json:"title,omitempty"`
+
 	// URI template
 	URITemplate *URITemplate `json:"uriTemplate"`
 
@@ -141,6 +164,9 @@ type ResourceTemplate struct {
 
 	// Embed Annotated struct
 	Annotated
+
+	// Completion providers keyed by template variable
+	completions map[string]CompletionProvider
 }
 
 // ListResourcesResponse represents the response for listing resources
@@ -194,14 +220,21 @@ func WithTemplateMIMEType(mimeType string) ResourceTemplateOption {
 	}
 }
 
+// WithTemplateCompletion sets the provider suggesting values for a template variable in completion/complete requests.
+func WithTemplateCompletion(variable string, provider CompletionProvider) ResourceTemplateOption {
+	return func(t *ResourceTemplate) {
+		if t.completions == nil {
+			t.completions = make(map[string]CompletionProvider)
+		}
+		t.completions[variable] = provider
+	}
+}
+
 // WithTemplateAnnotations sets the annotations for the ResourceTemplate.
 func WithTemplateAnnotations(audience []Role, priority float64) ResourceTemplateOption {
 	return func(t *ResourceTemplate) {
 		if t.Annotations == nil {
-			t.Annotations = &struct {
-				Audience []Role  `json:"audience,omitempty"`
-				Priority float64 `json:"priority,omitempty"`
-			}{}
+			t.Annotations = &Annotations{}
 		}
 		t.Annotations.Audience = audience
 		t.Annotations.Priority = priority
@@ -0,0 +1,100 @@
+// Tencent is pleased to support the open source community by making trpc-mcp-go available.
+//
+// Copyright (C) 2025 Tencent.  All rights reserved.
+//
+// trpc-mcp-go is licensed under the Apache License Version 2.0.
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Root represents a root directory or file that the server can operate on
+// Corresponds to the "Root" definition in schema.json
+type Root struct {
+	// URI identifying the root, must start with file:// for now
+	URI string `json:"uri"`
+
+	// Name is an optional human-readable name for the root
+	Name string `json:"name,omitempty"`
+}
+
+// ListRootsRequest describes a request from the server to get the list of root URIs from the client
+// Corresponds to the "ListRootsRequest" definition in schema.json
+type ListRootsRequest struct {
+	Request
+}
+
+// ListRootsResult describes the client's response to a roots/list request
+// Corresponds to the "ListRootsResult" definition in schema.json
+type ListRootsResult struct {
+	Result
+	Roots []Root `json:"roots"`
+}
+
+// RootsListChangedNotification is sent by the client when its list of roots has changed
+// Corresponds to the "RootsListChangedNotification" definition in schema.json
+type RootsListChangedNotification struct {
+	Notification
+}
+
+// NewRootsListChangedNotification creates a roots list changed notification
+func NewRootsListChangedNotification() *JSONRPCNotification {
+	return newJSONRPCNotification(Notification{Method: MethodNotificationsRootsListChanged, Params: NotificationParams{}})
+}
+
+// RootsListChangedHandler is called when a client reports that its roots have changed.
+// The context carries the client session, so ListRoots can be used to fetch the new roots.
+type RootsListChangedHandler func(ctx context.Context)
+
+// ListRoots asks the client connected to the current session for its list of roots.
+// It must be called with the context passed to a handler, and the client must have
+// advertised the roots capability during initialization.
+func ListRoots(ctx context.Context) (*ListRootsResult, error) {
+	capabilities, ok := getClientCapabilitiesFromContext(ctx)
+	if !ok || capabilities.Roots == nil {
+		return nil, fmt.Errorf("%w: roots", ErrClientCapabilityNotSupported)
+	}
+
+	var result ListRootsResult
+	if err := requestClient(ctx, MethodRootsList, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// clientRoots holds the roots a client exposes to the server
+type clientRoots struct {
+	// Whether roots are exposed to the server
+	enabled bool
+
+	// Current roots
+	roots []Root
+
+	// Mutex for roots
+	mu sync.RWMutex
+}
+
+// set replaces the current roots
+func (r *clientRoots) set(roots []Root) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.roots = append([]Root{}, roots...)
+}
+
+// get returns a copy of the current roots
+func (r *clientRoots) get() []Root {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]Root{}, r.roots...)
+}
+
+// handleListRoots answers roots/list requests from the server
+func (r *clientRoots) handleListRoots(ctx context.Context, req *ListRootsRequest) (*ListRootsResult, error) {
+	return &ListRootsResult{Roots: r.get()}, nil
+}
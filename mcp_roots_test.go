@@ -0,0 +1,112 @@
+// Tencent is pleased to support the open source community by making trpc-mcp-go available.
+//
+// Copyright (C) 2025 Tencent.  All rights reserved.
+//
+// trpc-mcp-go is licensed under the Apache License Version 2.0.
+
+package mcp
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListRoots_WithoutCapability(t *testing.T) {
+	ctx := setSessionToContext(context.Background(), newSession())
+
+	_, err := ListRoots(ctx)
+	assert.ErrorIs(t, err, ErrClientCapabilityNotSupported)
+}
+
+func TestClient_WithRoots(t *testing.T) {
+	changedRoots := make(chan []Root, 10)
+	server := NewServer("Test-Server", "1.0.0",
+		WithServerPath("/mcp"),
+		WithRootsListChangedHandler(func(ctx context.Context) {
+			result, err := ListRoots(ctx)
+			if err == nil {
+				changedRoots <- result.Roots
+			}
+		}),
+	)
+	server.RegisterTool(NewTool("list-roots"), func(ctx context.Context, req *CallToolRequest) (*CallToolResult, error) {
+		result, err := ListRoots(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var text string
+		for _, root := range result.Roots {
+			text += root.URI + ";"
+		}
+		return NewTextResult(text), nil
+	})
+
+	httpServer := httptest.NewServer(server.HTTPHandler())
+	defer httpServer.Close()
+
+	client, err := NewClient(httpServer.URL+"/mcp", Implementation{Name: "Test-Client", Version: "1.0.0"},
+		WithRoots([]Root{{URI: "file:///workspace", Name: "workspace"}}),
+	)
+	require.NoError(t, err)
+	defer client.Close()
+	assert.Equal(t, map[string]interface{}{"listChanged": true}, client.capabilities["roots"])
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err = client.Initialize(ctx, &InitializeRequest{})
+	require.NoError(t, err)
+
+	// Tool handlers can list roots during a request
+	result, err := client.CallTool(ctx, &CallToolRequest{Params: CallToolParams{Name: "list-roots"}})
+	require.NoError(t, err)
+	assert.Equal(t, "file:///workspace;", result.Content[0].(TextContent).Text)
+
+	// Updating roots notifies the server, which fetches them over the GET SSE stream
+	newRoots := []Root{{URI: "file:///workspace"}, {URI: "file:///tmp"}}
+	require.Eventually(t, func() bool {
+		if err := client.SetRoots(ctx, newRoots); err != nil {
+			return false
+		}
+		select {
+		case roots := <-changedRoots:
+			return assert.Equal(t, newRoots, roots)
+		case <-time.After(200 * time.Millisecond):
+			return false
+		}
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestClient_SetRootsAfterInitialize(t *testing.T) {
+	changed := make(chan struct{}, 10)
+	server := NewServer("Test-Server", "1.0.0",
+		WithServerPath("/mcp"),
+		WithRootsListChangedHandler(func(ctx context.Context) {
+			changed <- struct{}{}
+		}),
+	)
+	httpServer := httptest.NewServer(server.HTTPHandler())
+	defer httpServer.Close()
+
+	client, err := NewClient(httpServer.URL+"/mcp", Implementation{Name: "Test-Client", Version: "1.0.0"})
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err = client.Initialize(ctx, &InitializeRequest{})
+	require.NoError(t, err)
+
+	// The server is not notified of roots it was never told about
+	require.NoError(t, client.SetRoots(ctx, []Root{{URI: "file:///workspace"}}))
+	assert.Equal(t, map[string]interface{}{"listChanged": true}, client.getCapabilities()["roots"])
+	select {
+	case <-changed:
+		t.Fatal("unexpected roots list_changed notification")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
@@ -0,0 +1,191 @@
+// Tencent is pleased to support the open source community by making trpc-mcp-go available.
+//
+// Copyright (C) 2025 Tencent.  All rights reserved.
+//
+// trpc-mcp-go is licensed under the Apache License Version 2.0.
+
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// IncludeContext describes which MCP server context the client should attach to a sampling request
+type IncludeContext string
+
+const (
+	// IncludeContextNone requests no additional context
+	IncludeContextNone IncludeContext = "none"
+
+	// IncludeContextThisServer requests context from the requesting server only
+	IncludeContextThisServer IncludeContext = "thisServer"
+
+	// IncludeContextAllServers requests context from all servers connected to the client
+	IncludeContextAllServers IncludeContext = "allServers"
+)
+
+// SamplingMessage describes a message issued to or received from an LLM API
+// Corresponds to the "SamplingMessage" definition in schema.json
+type SamplingMessage struct {
+	// Message role
+	Role Role `json:"role"`
+
+	// Message content (text, image or audio)
+	Content Content `json:"content"`
+}
+
+// UnmarshalJSON implements custom unmarshaling for SamplingMessage to handle polymorphic Content.
+func (m *SamplingMessage) UnmarshalJSON(data []byte) error {
+	type Alias SamplingMessage
+	temp := &struct {
+		Content json.RawMessage `json:"content"`
+		*Alias
+	}{
+		Alias: (*Alias)(m),
+	}
+
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return fmt.Errorf("failed to unmarshal sampling message structure: %w", err)
+	}
+
+	if len(temp.Content) == 0 || string(temp.Content) == "null" {
+		m.Content = nil
+		return nil
+	}
+
+	content, err := unmarshalContent(temp.Content)
+	if err != nil {
+		return fmt.Errorf("failed to parse sampling message content: %w", err)
+	}
+	m.Content = content
+	return nil
+}
+
+// ModelHint provides hints to use for model selection
+// Corresponds to the "ModelHint" definition in schema.json
+type ModelHint struct {
+	// Name is a hint for a model name, which may be a substring of the actual model name
+	Name string `json:"name,omitempty"`
+}
+
+// ModelPreferences describes the server's preferences for model selection during sampling
+// Corresponds to the "ModelPreferences" definition in schema.json
+type ModelPreferences struct {
+	// Hints are optional hints to use for model selection, evaluated in order
+	Hints []ModelHint `json:"hints,omitempty"`
+
+	// CostPriority indicates how much to prioritize cost (0-1)
+	CostPriority float64 `json:"costPriority,omitempty"`
+
+	// SpeedPriority indicates how much to prioritize sampling speed (0-1)
+	SpeedPriority float64 `json:"speedPriority,omitempty"`
+
+	// IntelligencePriority indicates how much to prioritize intelligence and capabilities (0-1)
+	IntelligencePriority float64 `json:"intelligencePriority,omitempty"`
+}
+
+// CreateMessageParams describes the parameters of a sampling/createMessage request
+// Corresponds to schema.json CreateMessageRequest.params
+type CreateMessageParams struct {
+	// Messages to send to the LLM
+	Messages []SamplingMessage `json:"messages"`
+
+	// ModelPreferences are the server's preferences for which model to select (optional)
+	ModelPreferences *ModelPreferences `json:"modelPreferences,omitempty"`
+
+	// SystemPrompt is an optional system prompt the server wants to use
+	SystemPrompt string `json:"systemPrompt,omitempty"`
+
+	// IncludeContext requests that context from MCP servers be included (optional)
+	IncludeContext IncludeContext `json:"includeContext,omitempty"`
+
+	// Temperature for sampling (optional)
+	Temperature *float64 `json:"temperature,omitempty"`
+
+	// MaxTokens is the maximum number of tokens to sample
+	MaxTokens int `json:"maxTokens"`
+
+	// StopSequences for sampling (optional)
+	StopSequences []string `json:"stopSequences,omitempty"`
+
+	// Metadata is optional provider-specific metadata
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// CreateMessageRequest describes a request from the server to sample an LLM via the client
+// Corresponds to the "CreateMessageRequest" definition in schema.json
+type CreateMessageRequest struct {
+	Request
+	Params CreateMessageParams `json:"params"`
+}
+
+// CreateMessageResult describes the client's response to a sampling/createMessage request
+// Corresponds to the "CreateMessageResult" definition in schema.json
+type CreateMessageResult struct {
+	Result
+	SamplingMessage
+
+	// Model is the name of the model that generated the message
+	Model string `json:"model"`
+
+	// StopReason is the reason why sampling stopped, if known
+	StopReason string `json:"stopReason,omitempty"`
+}
+
+// UnmarshalJSON implements custom unmarshaling for CreateMessageResult.
+// It is required because the embedded SamplingMessage would otherwise hijack unmarshaling.
+func (r *CreateMessageResult) UnmarshalJSON(data []byte) error {
+	var message SamplingMessage
+	if err := json.Unmarshal(data, &message); err != nil {
+		return err
+	}
+
+	var fields struct {
+		Meta       map[string]interface{} `json:"_meta,omitempty"`
+		Model      string                 `json:"model"`
+		StopReason string                 `json:"stopReason,omitempty"`
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	r.Meta = fields.Meta
+	r.SamplingMessage = message
+	r.Model = fields.Model
+	r.StopReason = fields.StopReason
+	return nil
+}
+
+// Stop reasons defined by the specification
+const (
+	// StopReasonEndTurn indicates the model finished its turn
+	StopReasonEndTurn = "endTurn"
+
+	// StopReasonStopSequence indicates a stop sequence was encountered
+	StopReasonStopSequence = "stopSequence"
+
+	// StopReasonMaxTokens indicates the token limit was reached
+	StopReasonMaxTokens = "maxTokens"
+)
+
+// RequestSampling asks the client connected to the current session to sample its LLM.
+// It must be called with the context passed to a tool (or other request) handler, and
+// the client must have advertised the sampling capability during initialization.
+func RequestSampling(ctx context.Context, params *CreateMessageParams) (*CreateMessageResult, error) {
+	if params == nil {
+		return nil, fmt.Errorf("sampling params cannot be nil")
+	}
+
+	capabilities, ok := getClientCapabilitiesFromContext(ctx)
+	if !ok || capabilities.Sampling == nil {
+		return nil, fmt.Errorf("%w: sampling", ErrClientCapabilityNotSupported)
+	}
+
+	var result CreateMessageResult
+	if err := requestClient(ctx, MethodSamplingCreateMessage, params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,169 @@
+// Tencent is pleased to support the open source community by making trpc-mcp-go available.
+//
+// Copyright (C) 2025 Tencent.  All rights reserved.
+//
+// trpc-mcp-go is licensed under the Apache License Version 2.0.
+
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"trpc.group/trpc-go/trpc-mcp-go/internal/httputil"
+)
+
+// postJSONRPC posts a JSON-RPC message to the test server
+func postJSONRPC(t *testing.T, url, sessionID string, message interface{}) *http.Response {
+	body, err := json.Marshal(message)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	if sessionID != "" {
+		req.Header.Set(httputil.SessionIDHeader, sessionID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	return resp
+}
+
+func TestRequestSampling_WithoutCapability(t *testing.T) {
+	session := newSession()
+	ctx := setSessionToContext(context.Background(), session)
+
+	_, err := RequestSampling(ctx, &CreateMessageParams{MaxTokens: 10})
+	assert.ErrorIs(t, err, ErrClientCapabilityNotSupported)
+
+	session.SetData(sessionDataKeyClientCapabilities, &ClientCapabilities{Sampling: &SamplingCapability{}})
+	_, err = RequestSampling(ctx, &CreateMessageParams{MaxTokens: 10})
+	assert.ErrorIs(t, err, ErrClientRequestNotSupported)
+}
+
+func TestClientResponseRouter_Deliver(t *testing.T) {
+	router := newClientResponseRouter()
+
+	id, ch := router.register("session-1")
+
+	// Responses from another session are rejected
+	assert.False(t, router.deliver("session-2", float64(id), json.RawMessage(`{}`)))
+
+	// Numeric IDs decoded from JSON match the original integer ID
+	assert.True(t, router.deliver("session-1", float64(id), json.RawMessage(`{"ok":true}`)))
+	assert.JSONEq(t, `{"ok":true}`, string(<-ch))
+
+	// Delivered requests are removed
+	assert.False(t, router.deliver("session-1", float64(id), json.RawMessage(`{}`)))
+}
+
+func TestRequestSampling_StreamableHTTP(t *testing.T) {
+	server := NewServer("Test-Server", "1.0.0", WithServerPath("/mcp"))
+	server.RegisterTool(NewTool("ask"), func(ctx context.Context, req *CallToolRequest) (*CallToolResult, error) {
+		result, err := RequestSampling(ctx, &CreateMessageParams{
+			Messages: []SamplingMessage{
+				{Role: RoleUser, Content: NewTextContent("What is the capital of France?")},
+			},
+			MaxTokens: 100,
+		})
+		if err != nil {
+			return nil, err
+		}
+		text, _ := result.Content.(TextContent)
+		return NewTextResult(result.Model + ": " + text.Text), nil
+	})
+
+	httpServer := httptest.NewServer(server.HTTPHandler())
+	defer httpServer.Close()
+	url := httpServer.URL + "/mcp"
+
+	// Initialize with sampling capability
+	initResp := postJSONRPC(t, url, "", map[string]interface{}{
+		"jsonrpc": JSONRPCVersion,
+		"id":      1,
+		"method":  MethodInitialize,
+		"params": map[string]interface{}{
+			"protocolVersion": ProtocolVersion_2025_03_26,
+			"clientInfo":      map[string]interface{}{"name": "test-client", "version": "1.0.0"},
+			"capabilities":    map[string]interface{}{"sampling": map[string]interface{}{}},
+		},
+	})
+	sessionID := initResp.Header.Get(httputil.SessionIDHeader)
+	initResp.Body.Close()
+	require.NotEmpty(t, sessionID)
+
+	// Call the tool over POST SSE
+	callResp := postJSONRPC(t, url, sessionID, map[string]interface{}{
+		"jsonrpc": JSONRPCVersion,
+		"id":      2,
+		"method":  MethodToolsCall,
+		"params":  map[string]interface{}{"name": "ask"},
+	})
+	defer callResp.Body.Close()
+	require.Equal(t, http.StatusOK, callResp.StatusCode)
+
+	events := make(chan map[string]interface{}, 10)
+	go func() {
+		defer close(events)
+		scanner := bufio.NewScanner(callResp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if !strings.HasPrefix(line, "data: ") {
+				continue
+			}
+			var msg map[string]interface{}
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &msg); err == nil {
+				events <- msg
+			}
+		}
+	}()
+
+	// The first message is the sampling request
+	var samplingReq map[string]interface{}
+	select {
+	case samplingReq = <-events:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for sampling request")
+	}
+	assert.Equal(t, MethodSamplingCreateMessage, samplingReq["method"])
+	require.NotNil(t, samplingReq["id"])
+
+	// Post the sampling result back to the server
+	resultResp := postJSONRPC(t, url, sessionID, map[string]interface{}{
+		"jsonrpc": JSONRPCVersion,
+		"id":      samplingReq["id"],
+		"result": map[string]interface{}{
+			"role":       "assistant",
+			"content":    map[string]interface{}{"type": "text", "text": "Paris"},
+			"model":      "test-model",
+			"stopReason": StopReasonEndTurn,
+		},
+	})
+	resultResp.Body.Close()
+	assert.Equal(t, http.StatusAccepted, resultResp.StatusCode)
+
+	// The final message is the tool result
+	var final map[string]interface{}
+	select {
+	case final = <-events:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for tool result")
+	}
+	assert.Equal(t, float64(2), final["id"])
+	result, ok := final["result"].(map[string]interface{})
+	require.True(t, ok)
+	content := result["content"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "test-model: Paris", content["text"])
+}
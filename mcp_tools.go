@@ -57,7 +57,26 @@ type RequestMeta struct {
 type CallToolResult struct {
 	Result
 	Content []Content `json:"content"`
-	IsError bool      `json:"isError,omitempty"`
+
+	// StructuredContent is an optional JSON object conforming to the tool's output schema
+	StructuredContent interface{} `json:"structuredContent,omitempty"`
+
+	IsError bool `json:"isError,omitempty"`
+}
+
+// UnmarshalStructuredContent decodes the structured content of the result into v.
+func (r *CallToolResult) UnmarshalStructuredContent(v interface{}) error {
+	if r.StructuredContent == nil {
+		return fmt.Errorf("structured content is missing")
+	}
+	data, err := json.Marshal(r.StructuredContent)
+	if err != nil {
+		return fmt.Errorf("failed to marshal structured content: %w", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to unmarshal structured content: %w", err)
+	}
+	return nil
 }
 
 // ResultMeta represents result metadata
@@ -75,6 +94,9 @@ type Tool struct {
 	// Tool name
 	Name string `json:"name"`
 
+	// Human-readable display name (optional, since 2025-06-18)
+	Title string `json:"title,omitempty"`
+
 	// Tool description
 	Description string `json:"description,omitempty"`
 
@@ -83,15 +105,62 @@ type Tool struct {
 
 	// Raw schema (for custom schemas)
 	RawInputSchema json.RawMessage `json:"-"`
+
+	// Output schema of the structured content returned by the tool (optional)
+	OutputSchema *openapi3.Schema `json:"outputSchema,omitempty"`
+
+	// Hints describing the tool's behavior (optional)
+	Annotations *ToolAnnotations `json:"annotations,omitempty"`
+
+	// Whether the server skips validating call arguments against the input schema
+	skipInputValidation bool
+}
+
+// ToolArgumentError describes an argument of a tool call which does not match the tool's input schema
+type ToolArgumentError struct {
+	// Path of the argument, e.g. "location.city", empty for the arguments object itself
+	Field string `json:"field"`
+
+	// Reason of the mismatch
+	Message string `json:"message"`
+}
+
+// ToolAnnotations describes additional properties of a tool's behavior.
+// All hints are advisory, clients should not rely on them for security decisions
+// when the server is untrusted.
+type ToolAnnotations struct {
+	// Human-readable title for the tool
+	Title string `json:"title,omitempty"`
+
+	// If true, the tool does not modify its environment (default: false)
+	ReadOnlyHint *bool `json:"readOnlyHint,omitempty"`
+
+	// If true, the tool may perform destructive updates, only meaningful when not read-only (default: true)
+	DestructiveHint *bool `json:"destructiveHint,omitempty"`
+
+	// If true, calling the tool repeatedly with the same arguments has no additional effect (default: false)
+	IdempotentHint *bool `json:"idempotentHint,omitempty"`
+
+	// If true, the tool may interact with an open world of external entities (default: true)
+	OpenWorldHint *bool `json:"openWorldHint,omitempty"`
 }
 
 // toolHandler defines the function type for handling tool execution
 type toolHandler func(ctx context.Context, req *CallToolRequest) (*CallToolResult, error)
 
+// ServerTool pairs a tool with its handler, for replacing the registered tools at once
+type ServerTool struct {
+	Tool    *Tool
+	Handler toolHandler
+}
+
 // registeredTool combines a Tool with its handler function
 type registeredTool struct {
 	Tool    *Tool
 	Handler toolHandler
+
+	// Registration sequence number, used for pagination cursors
+	seq uint64
 }
 
 // ToolOption represents a function that configures a Tool
@@ -126,6 +195,91 @@ func NewTool(
 	return tool
 }
 
+// WithTitle sets the human-readable display name of the tool
+func WithTitle(title string) ToolOption {
+	return func(t *Tool) {
+		t.Title = title
+	}
+}
+
+// WithOutputSchema sets the schema of the structured content returned by the tool
+func WithOutputSchema(schema *openapi3.Schema) ToolOption {
+	return func(t *Tool) {
+		t.OutputSchema = schema
+	}
+}
+
+// WithToolAnnotations sets the annotations of the tool
+func WithToolAnnotations(annotations ToolAnnotations) ToolOption {
+	return func(t *Tool) {
+		t.Annotations = &annotations
+	}
+}
+
+// WithReadOnlyHint declares whether the tool does not modify its environment
+func WithReadOnlyHint(readOnly bool) ToolOption {
+	return func(t *Tool) {
+		t.ensureAnnotations().ReadOnlyHint = &readOnly
+	}
+}
+
+// WithDestructiveHint declares whether the tool may perform destructive updates
+func WithDestructiveHint(destructive bool) ToolOption {
+	return func(t *Tool) {
+		t.ensureAnnotations().DestructiveHint = &destructive
+	}
+}
+
+// WithIdempotentHint declares whether repeated calls with the same arguments have no additional effect
+func WithIdempotentHint(idempotent bool) ToolOption {
+	return func(t *Tool) {
+		t.ensureAnnotations().IdempotentHint = &idempotent
+	}
+}
+
+// WithOpenWorldHint declares whether the tool may interact with external entities
+func WithOpenWorldHint(openWorld bool) ToolOption {
+	return func(t *Tool) {
+		t.ensureAnnotations().OpenWorldHint = &openWorld
+	}
+}
+
+// ensureAnnotations returns the annotations of the tool, creating them if needed
+func (t *Tool) ensureAnnotations() *ToolAnnotations {
+	if t.Annotations == nil {
+		t.Annotations = &ToolAnnotations{}
+	}
+	return t.Annotations
+}
+
+// WithoutInputValidation disables the validation of call arguments against the tool's input schema,
+// leaving it to the handler
+func WithoutInputValidation() ToolOption {
+	return func(t *Tool) {
+		t.skipInputValidation = true
+	}
+}
+
+// addInputProperty adds a property to the tool's input schema.
+// Properties marked by Required are added to the required properties of the input schema.
+func addInputProperty(t *Tool, name string, schema *openapi3.Schema) {
+	if len(schema.Required) > 0 {
+		t.InputSchema.Required = append(t.InputSchema.Required, name)
+	}
+	// The marker set by Required is not a property name, keeping it would make the schema unsatisfiable
+	required := schema.Required[:0]
+	for _, property := range schema.Required {
+		if property != requiredMarker {
+			required = append(required, property)
+		}
+	}
+	if len(required) == 0 {
+		required = nil
+	}
+	schema.Required = required
+	t.InputSchema.Properties[name] = openapi3.NewSchemaRef("", schema)
+}
+
 // WithDescription common option function
 func WithDescription(description string) ToolOption {
 	return func(t *Tool) {
@@ -142,10 +296,7 @@ func WithString(name string, opts ...PropertyOption) ToolOption {
 		for _, opt := range opts {
 			opt(schema)
 		}
-		t.InputSchema.Properties[name] = openapi3.NewSchemaRef("", schema)
-		if schema.Required != nil && len(schema.Required) > 0 {
-			t.InputSchema.Required = append(t.InputSchema.Required, name)
-		}
+		addInputProperty(t, name, schema)
 	}
 }
 
@@ -158,10 +309,7 @@ func WithNumber(name string, opts ...PropertyOption) ToolOption {
 		for _, opt := range opts {
 			opt(schema)
 		}
-		t.InputSchema.Properties[name] = openapi3.NewSchemaRef("", schema)
-		if schema.Required != nil && len(schema.Required) > 0 {
-			t.InputSchema.Required = append(t.InputSchema.Required, name)
-		}
+		addInputProperty(t, name, schema)
 	}
 }
 
@@ -174,10 +322,7 @@ func WithInteger(name string, opts ...PropertyOption) ToolOption {
 		for _, opt := range opts {
 			opt(schema)
 		}
-		t.InputSchema.Properties[name] = openapi3.NewSchemaRef("", schema)
-		if schema.Required != nil && len(schema.Required) > 0 {
-			t.InputSchema.Required = append(t.InputSchema.Required, name)
-		}
+		addInputProperty(t, name, schema)
 	}
 }
 
@@ -190,10 +335,7 @@ func WithBoolean(name string, opts ...PropertyOption) ToolOption {
 		for _, opt := range opts {
 			opt(schema)
 		}
-		t.InputSchema.Properties[name] = openapi3.NewSchemaRef("", schema)
-		if schema.Required != nil && len(schema.Required) > 0 {
-			t.InputSchema.Required = append(t.InputSchema.Required, name)
-		}
+		addInputProperty(t, name, schema)
 	}
 }
 
@@ -207,10 +349,7 @@ func WithObject(name string, opts ...PropertyOption) ToolOption {
 		for _, opt := range opts {
 			opt(schema)
 		}
-		t.InputSchema.Properties[name] = openapi3.NewSchemaRef("", schema)
-		if schema.Required != nil && len(schema.Required) > 0 {
-			t.InputSchema.Required = append(t.InputSchema.Required, name)
-		}
+		addInputProperty(t, name, schema)
 	}
 }
 
@@ -221,10 +360,13 @@ func Description(desc string) PropertyOption {
 	}
 }
 
+// requiredMarker marks a parameter as required until it is added to the input schema
+const requiredMarker = "true"
+
 // Required marks the parameter as required.
 func Required() PropertyOption {
 	return func(s *openapi3.Schema) {
-		s.Required = []string{"true"}
+		s.Required = []string{requiredMarker}
 	}
 }
 
@@ -269,10 +411,7 @@ func WithArray(name string, opts ...PropertyOption) ToolOption {
 		for _, opt := range opts {
 			opt(schema)
 		}
-		t.InputSchema.Properties[name] = openapi3.NewSchemaRef("", schema)
-		if schema.Required != nil && len(schema.Required) > 0 {
-			t.InputSchema.Required = append(t.InputSchema.Required, name)
-		}
+		addInputProperty(t, name, schema)
 	}
 }
 
@@ -313,6 +452,19 @@ func NewTextResult(text string) *CallToolResult {
 	}
 }
 
+// NewStructuredResult creates a new result carrying v as structured content.
+// The JSON encoding of v is also added as text content for clients that don't support structured output.
+func NewStructuredResult(v any) *CallToolResult {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return NewErrorResult(fmt.Sprintf("failed to marshal structured content: %v", err))
+	}
+	return &CallToolResult{
+		Content:           []Content{NewTextContent(string(data))},
+		StructuredContent: v,
+	}
+}
+
 // NewErrorResult creates a new error result.
 func NewErrorResult(text string) *CallToolResult {
 	return &CallToolResult{
@@ -336,6 +488,10 @@ func parseCallToolResult(rawMessage *json.RawMessage) (*CallToolResult, error) {
 		}
 	}
 
+	if structuredContent, ok := jsonContent["structuredContent"]; ok {
+		result.StructuredContent = structuredContent
+	}
+
 	isError, ok := jsonContent["isError"]
 	if ok {
 		if isErrorBool, ok := isError.(bool); ok {
@@ -372,51 +528,85 @@ func parseCallToolResult(rawMessage *json.RawMessage) (*CallToolResult, error) {
 	return &result, nil
 }
 
+// parseContent parses content of any type, keeping content of unknown types as UnknownContent
 func parseContent(contentMap map[string]any) (Content, error) {
-	contentType := extractString(contentMap, "type")
-
-	switch contentType {
-	case "text":
-		return parseTextContent(contentMap)
-	case "image":
-		return parseImageContent(contentMap)
-	case "resource":
-		return parseResourceContent(contentMap)
-	default:
-		return nil, fmt.Errorf("unsupported content type: %s", contentType)
+	data, err := json.Marshal(contentMap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal content: %w", err)
 	}
+	return unmarshalContent(data)
 }
 
-// parseTextContent parses text content
-func parseTextContent(contentMap map[string]any) (Content, error) {
-	text := extractString(contentMap, "text")
-	if text == "" {
-		return nil, fmt.Errorf("text is missing")
+// unmarshalContent parses the JSON of content of any type, keeping content of unknown types as UnknownContent
+func unmarshalContent(data []byte) (Content, error) {
+	var header struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal content: %w", err)
 	}
-	return NewTextContent(text), nil
-}
 
-// parseImageContent parses image content
-func parseImageContent(contentMap map[string]any) (Content, error) {
-	data := extractString(contentMap, "data")
-	mimeType := extractString(contentMap, "mimeType")
-	if data == "" || mimeType == "" {
-		return nil, fmt.Errorf("image data or mimeType is missing")
+	switch header.Type {
+	case ContentTypeText:
+		var content TextContent
+		if err := json.Unmarshal(data, &content); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal text content: %w", err)
+		}
+		return content, nil
+	case ContentTypeImage:
+		var content ImageContent
+		if err := json.Unmarshal(data, &content); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal image content: %w", err)
+		}
+		if content.Data == "" || content.MimeType == "" {
+			return nil, fmt.Errorf("image data or mimeType is missing")
+		}
+		return content, nil
+	case ContentTypeAudio:
+		var content AudioContent
+		if err := json.Unmarshal(data, &content); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal audio content: %w", err)
+		}
+		if content.Data == "" || content.MimeType == "" {
+			return nil, fmt.Errorf("audio data or mimeType is missing")
+		}
+		return content, nil
+	case ContentTypeResourceLink:
+		var content ResourceLink
+		if err := json.Unmarshal(data, &content); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal resource link: %w", err)
+		}
+		if content.URI == "" {
+			return nil, fmt.Errorf("resource link uri is missing")
+		}
+		return content, nil
+	case ContentTypeEmbeddedResource, legacyContentTypeEmbeddedResource:
+		return unmarshalEmbeddedResource(data)
+	default:
+		// Keep a copy, since the caller may reuse data
+		return UnknownContent{Type: header.Type, Raw: append(json.RawMessage(nil), data...)}, nil
 	}
-	return NewImageContent(data, mimeType), nil
 }
 
-// parseResourceContent parses resource content
-func parseResourceContent(contentMap map[string]any) (Content, error) {
-	resourceMap := extractMap(contentMap, "resource")
-	if resourceMap == nil {
+// unmarshalEmbeddedResource parses the JSON of embedded resource content
+func unmarshalEmbeddedResource(data []byte) (Content, error) {
+	var content struct {
+		Resource map[string]any `json:"resource"`
+		Annotated
+	}
+	if err := json.Unmarshal(data, &content); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal embedded resource: %w", err)
+	}
+	if content.Resource == nil {
 		return nil, fmt.Errorf("resource is missing")
 	}
-	resourceContents, err := parseResourceContents(resourceMap)
+	resourceContents, err := parseResourceContents(content.Resource)
 	if err != nil {
 		return nil, err
 	}
-	return NewEmbeddedResource(resourceContents), nil
+	resource := NewEmbeddedResource(resourceContents)
+	resource.Annotated = content.Annotated
+	return resource, nil
 }
 
 // extractString extracts a string value from a map by key
@@ -429,16 +619,6 @@ func extractString(data map[string]any, key string) string {
 	return ""
 }
 
-// extractMap extracts a map value from a map by key
-func extractMap(data map[string]any, key string) map[string]any {
-	if value, ok := data[key]; ok {
-		if m, ok := value.(map[string]any); ok {
-			return m
-		}
-	}
-	return nil
-}
-
 func parseResourceContents(contentMap map[string]any) (ResourceContents, error) {
 	uri := extractString(contentMap, "uri")
 	if uri == "" {
@@ -447,7 +627,8 @@ func parseResourceContents(contentMap map[string]any) (ResourceContents, error)
 
 	mimeType := extractString(contentMap, "mimeType")
 
-	if text := extractString(contentMap, "text"); text != "" {
+	// Empty text or blob is valid, so check for the presence of the fields
+	if text, ok := contentMap["text"].(string); ok {
 		return TextResourceContents{
 			URI:      uri,
 			MIMEType: mimeType,
@@ -455,7 +636,7 @@ func parseResourceContents(contentMap map[string]any) (ResourceContents, error)
 		}, nil
 	}
 
-	if blob := extractString(contentMap, "blob"); blob != "" {
+	if blob, ok := contentMap["blob"].(string); ok {
 		return BlobResourceContents{
 			URI:      uri,
 			MIMEType: mimeType,
@@ -8,9 +8,13 @@ package mcp
 
 import (
 	"context"
+	"encoding/json"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // MockTool is a mock tool implementation for testing
@@ -176,3 +180,133 @@ func TestMockTool(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, customResult, result)
 }
+
+func TestParseListToolsResultFromJSON_Metadata(t *testing.T) {
+	tool := NewTool("weather", WithTitle("Weather Lookup"), WithDescription("Get the weather"), WithString("city"),
+		WithOutputSchema(openapi3.NewObjectSchema().WithProperty("temperature", openapi3.NewFloat64Schema())),
+		WithReadOnlyHint(true), WithDestructiveHint(false), WithOpenWorldHint(true))
+	result := ListToolsResult{Tools: []Tool{*tool}}
+	result.Meta = map[string]interface{}{"trace": "abc"}
+
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	raw := json.RawMessage(data)
+
+	parsed, err := parseListToolsResultFromJSON(&raw, GetDefaultLogger())
+	require.NoError(t, err)
+	require.Len(t, parsed.Tools, 1)
+	assert.Equal(t, "Weather Lookup", parsed.Tools[0].Title)
+	assert.Equal(t, "Get the weather", parsed.Tools[0].Description)
+	assert.Equal(t, map[string]interface{}{"trace": "abc"}, parsed.Meta)
+	require.NotNil(t, parsed.Tools[0].OutputSchema)
+	assert.Contains(t, parsed.Tools[0].OutputSchema.Properties, "temperature")
+
+	annotations := parsed.Tools[0].Annotations
+	require.NotNil(t, annotations)
+	require.NotNil(t, annotations.ReadOnlyHint)
+	assert.True(t, *annotations.ReadOnlyHint)
+	require.NotNil(t, annotations.DestructiveHint)
+	assert.False(t, *annotations.DestructiveHint)
+	assert.Nil(t, annotations.IdempotentHint)
+	require.NotNil(t, annotations.OpenWorldHint)
+	assert.True(t, *annotations.OpenWorldHint)
+}
+
+func TestParseListToolsResultFromJSON_InvalidOptionalFields(t *testing.T) {
+	raw := json.RawMessage(`{"tools":[
+		{"name":"broken","inputSchema":{"type":"object"},"outputSchema":{"properties":"not-an-object"}},
+		{"name":"unannotated","inputSchema":{"type":"object"},"annotations":{"readOnlyHint":"yes"}},
+		{"name":"valid","inputSchema":{"type":"object"}}
+	]}`)
+
+	// Tools with an invalid optional field are kept without it
+	parsed, err := parseListToolsResultFromJSON(&raw, GetDefaultLogger())
+	require.NoError(t, err)
+	require.Len(t, parsed.Tools, 3)
+	assert.Equal(t, "broken", parsed.Tools[0].Name)
+	assert.Nil(t, parsed.Tools[0].OutputSchema)
+	assert.Equal(t, "unannotated", parsed.Tools[1].Name)
+	assert.Nil(t, parsed.Tools[1].Annotations)
+	assert.Equal(t, "valid", parsed.Tools[2].Name)
+}
+
+// listToolsWithoutLogger lists the tools of a server answering tools/list with the raw tools,
+// using a client without logger
+func listToolsWithoutLogger(t *testing.T, rawTools string) *ListToolsResult {
+	listRawTools := func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, req *ServerRequest) (JSONRPCMessage, error) {
+			if req.Method != MethodToolsList {
+				return next(ctx, req)
+			}
+			return json.RawMessage(`{"tools":` + rawTools + `}`), nil
+		}
+	}
+	server := NewServer("Test-Server", "1.0.0", WithServerPath("/mcp"), WithMiddleware(listRawTools))
+	httpServer := httptest.NewServer(server.HTTPHandler())
+	t.Cleanup(httpServer.Close)
+
+	client, err := NewClient(httpServer.URL+"/mcp", Implementation{Name: "Test-Client", Version: "1.0.0"})
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	_, err = client.Initialize(context.Background(), &InitializeRequest{})
+	require.NoError(t, err)
+
+	result, err := client.ListTools(context.Background(), &ListToolsRequest{})
+	require.NoError(t, err)
+	return result
+}
+
+func TestClient_ListToolsInvalidOutputSchema(t *testing.T) {
+	result := listToolsWithoutLogger(t, `[{"name":"broken","inputSchema":{"type":"object"},"outputSchema":{"properties":"not-an-object"}}]`)
+	require.Len(t, result.Tools, 1)
+	assert.Equal(t, "broken", result.Tools[0].Name)
+	assert.Nil(t, result.Tools[0].OutputSchema)
+}
+
+func TestClient_ListToolsInvalidAnnotations(t *testing.T) {
+	result := listToolsWithoutLogger(t, `[{"name":"unannotated","inputSchema":{"type":"object"},"annotations":{"readOnlyHint":"yes"}}]`)
+	require.Len(t, result.Tools, 1)
+	assert.Equal(t, "unannotated", result.Tools[0].Name)
+	assert.Nil(t, result.Tools[0].Annotations)
+}
+
+func TestParseCallToolResult_ContentRoundTrip(t *testing.T) {
+	annotations := &Annotations{
+		Audience:     []Role{RoleUser},
+		Priority:     0.5,
+		LastModified: "2025-01-12T15:00:58Z",
+	}
+	text := NewTextContent("hello")
+	text.Annotations = annotations
+	image := NewImageContent("aW1hZ2U=", "image/png")
+	audio := NewAudioContent("YXVkaW8=", "audio/wav")
+	audio.Annotations = annotations
+	link := NewResourceLink("file:///a.txt", "a", "file a", "text/plain")
+	link.Annotations = annotations
+	embedded := NewEmbeddedResource(TextResourceContents{URI: "file:///b.txt", Text: ""})
+	embedded.Annotations = annotations
+	unknown := UnknownContent{Type: "video", Raw: json.RawMessage(`{"type":"video","url":"https://example.com/v.mp4"}`)}
+
+	original := &CallToolResult{Content: []Content{text, image, audio, link, embedded, unknown}}
+	data, err := json.Marshal(original)
+	require.NoError(t, err)
+
+	raw := json.RawMessage(data)
+	parsed, err := parseCallToolResult(&raw)
+	require.NoError(t, err)
+	assert.Equal(t, original.Content, parsed.Content)
+
+	// Unknown content is passed on unchanged
+	data2, err := json.Marshal(parsed)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(data), string(data2))
+
+	// Prompt messages parse the same content types
+	for _, content := range original.Content {
+		messageData, err := json.Marshal(PromptMessage{Role: RoleAssistant, Content: content})
+		require.NoError(t, err)
+		var message PromptMessage
+		require.NoError(t, json.Unmarshal(messageData, &message))
+		assert.Equal(t, content, message.Content)
+	}
+}
@@ -0,0 +1,354 @@
+// Tencent is pleased to support the open source community by making trpc-mcp-go available.
+//
+// Copyright (C) 2025 Tencent.  All rights reserved.
+//
+// trpc-mcp-go is licensed under the Apache License Version 2.0.
+
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ErrInvalidToolArguments is returned by typed tool handlers when the arguments cannot be decoded,
+// the server answers such calls with an invalid params error
+var ErrInvalidToolArguments = errors.New("invalid tool arguments")
+
+// TypedToolHandler handles a tool call with arguments decoded into In
+type TypedToolHandler[In, Out any] func(ctx context.Context, in In) (Out, error)
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// NewTypedTool creates a tool whose input schema is derived from the struct type In.
+//
+// Each exported field becomes a property named after its json tag, fields without omitempty being required.
+// The jsonschema tag refines a property with comma separated options:
+//   - description=..., title=...: text describing the property, which may contain commas
+//     unless they are followed by another option
+//   - enum=...: an allowed value, repeated for each value
+//   - required, optional: overrides the requirement derived from the json tag
+//
+// Other commas in values, e.g. in enum values, are escaped with a backslash, written \\, in the struct tag.
+//
+// Nested structs become objects, and slices and arrays become arrays of their element schema.
+//
+// The handler receives the arguments decoded into In. Its result is returned as is when Out is
+// *CallToolResult, as text content when Out is a string, and as structured content otherwise.
+// It panics if In is not a struct or contains types which cannot be described by a schema.
+func NewTypedTool[In, Out any](name string, handler TypedToolHandler[In, Out], opts ...ToolOption) ServerTool {
+	inType := reflect.TypeOf((*In)(nil)).Elem()
+	if inType.Kind() == reflect.Ptr {
+		inType = inType.Elem()
+	}
+	if inType.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("typed tool %s: input type %s is not a struct", name, inType))
+	}
+	inputSchema, err := schemaForType(inType, map[reflect.Type]bool{})
+	if err != nil {
+		panic(fmt.Sprintf("typed tool %s: %v", name, err))
+	}
+
+	tool := NewTool(name, opts...)
+	// Properties added by options complement the derived ones
+	for propName, prop := range tool.InputSchema.Properties {
+		inputSchema.Properties[propName] = prop
+	}
+	inputSchema.Required = append(inputSchema.Required, tool.InputSchema.Required...)
+	tool.InputSchema = inputSchema
+
+	return ServerTool{
+		Tool: tool,
+		Handler: func(ctx context.Context, req *CallToolRequest) (*CallToolResult, error) {
+			in, err := decodeToolArguments[In](inputSchema, req.Params.Arguments)
+			if err != nil {
+				return nil, err
+			}
+			out, err := handler(ctx, in)
+			if err != nil {
+				return nil, err
+			}
+			return newTypedToolResult(out)
+		},
+	}
+}
+
+// decodeToolArguments decodes the tool call arguments into In
+func decodeToolArguments[In any](schema *openapi3.Schema, arguments map[string]interface{}) (In, error) {
+	var in In
+	for _, name := range schema.Required {
+		if _, ok := arguments[name]; !ok {
+			return in, fmt.Errorf("%w: missing required argument %q", ErrInvalidToolArguments, name)
+		}
+	}
+	if arguments == nil {
+		arguments = map[string]interface{}{}
+	}
+
+	data, err := json.Marshal(arguments)
+	if err != nil {
+		return in, fmt.Errorf("%w: %v", ErrInvalidToolArguments, err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&in); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			return in, fmt.Errorf("%w: argument %q must be %s, got %s",
+				ErrInvalidToolArguments, typeErr.Field, typeErr.Type, typeErr.Value)
+		}
+		return in, fmt.Errorf("%w: %v", ErrInvalidToolArguments, strings.TrimPrefix(err.Error(), "json: "))
+	}
+	return in, nil
+}
+
+// newTypedToolResult wraps the output of a typed tool handler into a tool result
+func newTypedToolResult(out any) (*CallToolResult, error) {
+	switch v := out.(type) {
+	case *CallToolResult:
+		if v == nil {
+			return nil, fmt.Errorf("tool returned a nil result")
+		}
+		return v, nil
+	case string:
+		return NewTextResult(v), nil
+	}
+
+	// Structured content must be a JSON object, other values are returned as JSON text
+	value := reflect.ValueOf(out)
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() == reflect.Struct || value.Kind() == reflect.Map {
+		return NewStructuredResult(out), nil
+	}
+	data, err := json.Marshal(out)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal tool output: %w", err)
+	}
+	return NewTextResult(string(data)), nil
+}
+
+// isInvalidToolArguments reports whether err was caused by arguments which could not be decoded
+func isInvalidToolArguments(err error) bool {
+	return errors.Is(err, ErrInvalidToolArguments)
+}
+
+// schemaForType builds the schema describing the JSON encoding of t
+func schemaForType(t reflect.Type, visiting map[reflect.Type]bool) (*openapi3.Schema, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeString}, Format: "date-time"}, nil
+	case t == rawMessageType:
+		return &openapi3.Schema{}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeString}}, nil
+	case reflect.Bool:
+		return &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeBoolean}}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeInteger}}, nil
+	case reflect.Float32, reflect.Float64:
+		return &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeNumber}}, nil
+	case reflect.Interface:
+		return &openapi3.Schema{}, nil
+	case reflect.Slice, reflect.Array:
+		// Byte slices are encoded as base64 strings
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeString}}, nil
+		}
+		items, err := schemaForType(t.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+		return &openapi3.Schema{
+			Type:  &openapi3.Types{openapi3.TypeArray},
+			Items: openapi3.NewSchemaRef("", items),
+		}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported map key type %s", t.Key())
+		}
+		values, err := schemaForType(t.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+		return &openapi3.Schema{
+			Type:                 &openapi3.Types{openapi3.TypeObject},
+			AdditionalProperties: openapi3.AdditionalProperties{Schema: openapi3.NewSchemaRef("", values)},
+		}, nil
+	case reflect.Struct:
+		if visiting[t] {
+			return nil, fmt.Errorf("recursive type %s is not supported", t)
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+
+		schema := &openapi3.Schema{
+			Type:       &openapi3.Types{openapi3.TypeObject},
+			Properties: make(openapi3.Schemas),
+			Required:   []string{},
+		}
+		if err := addStructFields(schema, t, visiting); err != nil {
+			return nil, err
+		}
+		return schema, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+// addStructFields adds the properties of the struct type t to schema, flattening embedded structs
+func addStructFields(schema *openapi3.Schema, t reflect.Type, visiting map[reflect.Type]bool) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		jsonTag := field.Tag.Get("json")
+		if jsonTag == "-" {
+			continue
+		}
+		name, jsonOpts, _ := strings.Cut(jsonTag, ",")
+
+		// Embedded structs without a name are flattened like encoding/json does
+		if field.Anonymous && name == "" {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				if err := addStructFields(schema, fieldType, visiting); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		fieldSchema, err := schemaForType(field.Type, visiting)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		required := !strings.Contains(","+jsonOpts+",", ",omitempty,")
+		if required, err = applySchemaTag(fieldSchema, field.Tag.Get("jsonschema"), required); err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+
+		schema.Properties[name] = openapi3.NewSchemaRef("", fieldSchema)
+		if required {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return nil
+}
+
+// applySchemaTag applies the options of a jsonschema tag to schema and returns whether the property is required
+func applySchemaTag(schema *openapi3.Schema, tag string, required bool) (bool, error) {
+	if tag == "" {
+		return required, nil
+	}
+	for _, option := range splitSchemaTag(tag) {
+		key, value, _ := strings.Cut(option, "=")
+		switch key {
+		case "required":
+			required = true
+		case "optional":
+			required = false
+		case "description":
+			schema.Description = value
+		case "title":
+			schema.Title = value
+		case "enum":
+			enumValue, err := parseEnumValue(schema, value)
+			if err != nil {
+				return required, err
+			}
+			schema.Enum = append(schema.Enum, enumValue)
+		default:
+			return required, fmt.Errorf("unknown jsonschema option %q", key)
+		}
+	}
+	return required, nil
+}
+
+// splitSchemaTag splits a jsonschema tag into its options at unescaped commas. Text following a
+// comma in a description or title is kept in it unless it starts another option.
+func splitSchemaTag(tag string) []string {
+	var segments []string
+	var segment strings.Builder
+	for i := 0; i < len(tag); i++ {
+		switch {
+		case tag[i] == '\\' && i+1 < len(tag) && tag[i+1] == ',':
+			segment.WriteByte(',')
+			i++
+		case tag[i] == ',':
+			segments = append(segments, segment.String())
+			segment.Reset()
+		default:
+			segment.WriteByte(tag[i])
+		}
+	}
+	segments = append(segments, segment.String())
+
+	var options []string
+	for _, segment := range segments {
+		if n := len(options); n > 0 && isTextSchemaOption(options[n-1]) && !isSchemaOption(segment) {
+			options[n-1] += "," + segment
+			continue
+		}
+		options = append(options, segment)
+	}
+	return options
+}
+
+// isTextSchemaOption reports whether a jsonschema option is a description or title
+func isTextSchemaOption(option string) bool {
+	return strings.HasPrefix(option, "description=") || strings.HasPrefix(option, "title=")
+}
+
+// isSchemaOption reports whether a part of a jsonschema tag starts an option
+func isSchemaOption(segment string) bool {
+	switch key, _, hasValue := strings.Cut(segment, "="); key {
+	case "description", "title", "enum":
+		return hasValue
+	case "required", "optional":
+		return !hasValue
+	default:
+		return false
+	}
+}
+
+// parseEnumValue converts an enum value of a jsonschema tag to the type of schema.
+// Numbers are kept as float64, matching the decoded JSON values they are validated against.
+func parseEnumValue(schema *openapi3.Schema, value string) (interface{}, error) {
+	switch {
+	case schema.Type.Is(openapi3.TypeInteger), schema.Type.Is(openapi3.TypeNumber):
+		return strconv.ParseFloat(value, 64)
+	case schema.Type.Is(openapi3.TypeBoolean):
+		return strconv.ParseBool(value)
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,212 @@
+// Tencent is pleased to support the open source community by making trpc-mcp-go available.
+//
+// Copyright (C) 2025 Tencent.  All rights reserved.
+//
+// trpc-mcp-go is licensed under the Apache License Version 2.0.
+
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type forecastLocation struct {
+	City    string `json:"city" jsonschema:"description=City name"`
+	Country string `json:"country,omitempty"`
+}
+
+type forecastInput struct {
+	Location forecastLocation `json:"location"`
+	Days     int              `json:"days,omitempty" jsonschema:"description=Number of days,enum=1,enum=3,enum=7"`
+	Units    string           `json:"units" jsonschema:"enum=metric,enum=imperial,optional"`
+	Fields   []string         `json:"fields,omitempty"`
+}
+
+type forecastOutput struct {
+	City        string    `json:"city"`
+	Temperature []float64 `json:"temperature"`
+}
+
+func TestNewTypedTool_InputSchema(t *testing.T) {
+	serverTool := NewTypedTool("forecast",
+		func(ctx context.Context, in forecastInput) (forecastOutput, error) {
+			return forecastOutput{}, nil
+		},
+		WithDescription("Weather forecast"),
+	)
+
+	data, err := json.Marshal(serverTool.Tool)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"name": "forecast",
+		"description": "Weather forecast",
+		"inputSchema": {
+			"type": "object",
+			"properties": {
+				"location": {
+					"type": "object",
+					"properties": {
+						"city": {"type": "string", "description": "City name"},
+						"country": {"type": "string"}
+					},
+					"required": ["city"]
+				},
+				"days": {"type": "integer", "description": "Number of days", "enum": [1, 3, 7]},
+				"units": {"type": "string", "enum": ["metric", "imperial"]},
+				"fields": {"type": "array", "items": {"type": "string"}}
+			},
+			"required": ["location"]
+		}
+	}`, string(data))
+
+	// Unsupported input types are rejected when the tool is created
+	assert.Panics(t, func() {
+		NewTypedTool("invalid", func(ctx context.Context, in string) (string, error) { return in, nil })
+	})
+	assert.Panics(t, func() {
+		NewTypedTool("invalid", func(ctx context.Context, in struct{ C chan int }) (string, error) { return "", nil })
+	})
+}
+
+func TestNewTypedTool_SchemaTagCommas(t *testing.T) {
+	type input struct {
+		City   string `json:"city" jsonschema:"description=City name, e.g. Paris"`
+		Format string `json:"format" jsonschema:"enum=a\\,b,enum=c,title=Format, one of a\\,b or c,optional"`
+	}
+	serverTool := NewTypedTool("commas", func(ctx context.Context, in input) (string, error) { return "", nil })
+
+	city := serverTool.Tool.InputSchema.Properties["city"].Value
+	assert.Equal(t, "City name, e.g. Paris", city.Description)
+	format := serverTool.Tool.InputSchema.Properties["format"].Value
+	assert.Equal(t, []interface{}{"a,b", "c"}, format.Enum)
+	assert.Equal(t, "Format, one of a,b or c", format.Title)
+	assert.Equal(t, []string{"city"}, serverTool.Tool.InputSchema.Required)
+
+	assert.Equal(t, []string{"description=x, y", "enum=1", "required"}, splitSchemaTag("description=x, y,enum=1,required"))
+}
+
+func TestNewTypedTool_Call(t *testing.T) {
+	// Disable schema validation so that the arguments reach the typed handler
+	manager := newToolManager().withInputValidation(false)
+	serverTool := NewTypedTool("forecast",
+		func(ctx context.Context, in forecastInput) (*forecastOutput, error) {
+			return &forecastOutput{City: in.Location.City, Temperature: make([]float64, in.Days)}, nil
+		})
+	manager.registerTool(serverTool.Tool, serverTool.Handler)
+	ctx := context.Background()
+
+	call := func(arguments map[string]interface{}) JSONRPCMessage {
+		req := newJSONRPCRequest("call-1", MethodToolsCall, map[string]interface{}{
+			"name":      "forecast",
+			"arguments": arguments,
+		})
+		result, err := manager.handleCallTool(ctx, req, nil)
+		require.NoError(t, err)
+		return result
+	}
+
+	// Arguments are decoded and the output is returned as structured content
+	result := call(map[string]interface{}{"location": map[string]interface{}{"city": "Shenzhen"}, "days": float64(3)})
+	callResult, ok := result.(*CallToolResult)
+	require.True(t, ok, "Expected *CallToolResult but got %T", result)
+	assert.Equal(t, &forecastOutput{City: "Shenzhen", Temperature: []float64{0, 0, 0}}, callResult.StructuredContent)
+	assert.JSONEq(t, `{"city":"Shenzhen","temperature":[0,0,0]}`, callResult.Content[0].(TextContent).Text)
+
+	// Invalid arguments are rejected with invalid params errors
+	testCases := []struct {
+		name      string
+		arguments map[string]interface{}
+		message   string
+	}{
+		{
+			name:      "missing required argument",
+			arguments: map[string]interface{}{"days": float64(3)},
+			message:   `missing required argument "location"`,
+		},
+		{
+			name:      "wrong type",
+			arguments: map[string]interface{}{"location": map[string]interface{}{"city": "Shenzhen"}, "days": "three"},
+			message:   `argument "days" must be int, got string`,
+		},
+		{
+			name:      "unknown argument",
+			arguments: map[string]interface{}{"location": map[string]interface{}{"city": "Shenzhen"}, "hours": float64(3)},
+			message:   `unknown field "hours"`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := call(tc.arguments)
+			errorResp, ok := result.(*JSONRPCError)
+			require.True(t, ok, "Expected *JSONRPCError but got %T", result)
+			assert.Equal(t, ErrCodeInvalidParams, errorResp.Error.Code)
+			assert.Contains(t, errorResp.Error.Message, tc.message)
+		})
+	}
+}
+
+func TestNewTypedTool_CallValidated(t *testing.T) {
+	// Arguments are validated against the derived schema by default
+	manager := newToolManager()
+	serverTool := NewTypedTool("forecast",
+		func(ctx context.Context, in forecastInput) (*forecastOutput, error) {
+			return &forecastOutput{City: in.Location.City, Temperature: make([]float64, in.Days)}, nil
+		})
+	manager.registerTool(serverTool.Tool, serverTool.Handler)
+	ctx := context.Background()
+
+	call := func(arguments map[string]interface{}) JSONRPCMessage {
+		req := newJSONRPCRequest("call-1", MethodToolsCall, map[string]interface{}{
+			"name":      "forecast",
+			"arguments": arguments,
+		})
+		result, err := manager.handleCallTool(ctx, req, nil)
+		require.NoError(t, err)
+		return result
+	}
+
+	result := call(map[string]interface{}{"location": map[string]interface{}{"city": "Shenzhen"}, "days": float64(7)})
+	callResult, ok := result.(*CallToolResult)
+	require.True(t, ok, "Expected *CallToolResult but got %T", result)
+	assert.Equal(t, &forecastOutput{City: "Shenzhen", Temperature: make([]float64, 7)}, callResult.StructuredContent)
+
+	// Each invalid argument is reported in the error data
+	result = call(map[string]interface{}{"location": map[string]interface{}{}, "days": float64(2), "units": "kelvin"})
+	errorResp, ok := result.(*JSONRPCError)
+	require.True(t, ok, "Expected *JSONRPCError but got %T", result)
+	assert.Equal(t, ErrCodeInvalidParams, errorResp.Error.Code)
+	assert.Equal(t, []ToolArgumentError{
+		{Field: "days", Message: "value is not one of the allowed values [1,3,7]"},
+		{Field: "location.city", Message: `property "city" is missing`},
+		{Field: "units", Message: `value is not one of the allowed values ["metric","imperial"]`},
+	}, errorResp.Error.Data)
+}
+
+func TestNewTypedTool_Results(t *testing.T) {
+	type empty struct{}
+	ctx := context.Background()
+	req := &CallToolRequest{}
+
+	text := NewTypedTool("text", func(ctx context.Context, in empty) (string, error) { return "hello", nil })
+	result, err := text.Handler(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, NewTextResult("hello"), result)
+
+	custom := NewTypedTool("custom", func(ctx context.Context, in empty) (*CallToolResult, error) {
+		return NewErrorResult("failed"), nil
+	})
+	result, err = custom.Handler(ctx, req)
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+
+	list := NewTypedTool("list", func(ctx context.Context, in empty) ([]int, error) { return []int{1, 2}, nil })
+	result, err = list.Handler(ctx, req)
+	require.NoError(t, err)
+	assert.Nil(t, result.StructuredContent)
+	assert.Equal(t, "[1,2]", result.Content[0].(TextContent).Text)
+}
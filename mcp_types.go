@@ -16,7 +16,12 @@ const (
 	// ContentTypeAudio represents audio content type
 	ContentTypeAudio = "audio"
 	// ContentTypeEmbeddedResource represents embedded resource content type
-	ContentTypeEmbeddedResource = "embedded_resource"
+	ContentTypeEmbeddedResource = "resource"
+	// ContentTypeResourceLink represents resource link content type (since 2025-06-18)
+	ContentTypeResourceLink = "resource_link"
+
+	// legacyContentTypeEmbeddedResource is the embedded resource content type sent by earlier versions of this package
+	legacyContentTypeEmbeddedResource = "embedded_resource"
 )
 
 // MCP protcol Layer
@@ -156,16 +161,25 @@ const (
 	RoleAssistant Role = "assistant"
 )
 
+// Annotations describe how clients should use or display an object.
+type Annotations struct {
+	// Audience describes who the object is intended for
+	Audience []Role `json:"audience,omitempty"`
+
+	// Priority describes how important the object is, from 0 (optional) to 1 (required)
+	Priority float64 `json:"priority,omitempty"`
+
+	// LastModified is the ISO 8601 time the object was last modified (since 2025-06-18)
+	LastModified string `json:"lastModified,omitempty"`
+}
+
 // Annotated describes an annotated resource.
 type Annotated struct {
 	// Annotations (optional)
-	Annotations *struct {
-		Audience []Role  `json:"audience,omitempty"`
-		Priority float64 `json:"priority,omitempty"`
-	} `json:"annotations,omitempty"`
+	Annotations *Annotations `json:"annotations,omitempty"`
 }
 
-// Content represents different types of message content (text, image, audio, embedded resource).
+// Content represents different types of message content (text, image, audio, embedded resource, resource link).
 type Content interface {
 	isContent()
 }
@@ -208,6 +222,40 @@ type EmbeddedResource struct {
 
 func (EmbeddedResource) isContent() {}
 
+// ResourceLink represents a link to a resource the client can read or subscribe to (since 2025-06-18)
+type ResourceLink struct {
+	Type        string `json:"type"`
+	URI         string `json:"uri"`
+	Name        string `json:"name"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+	Size        int64  `json:"size,omitempty"`
+	Annotated
+}
+
+func (ResourceLink) isContent() {}
+
+// UnknownContent represents content of a type this package does not know.
+// It keeps the raw JSON of the content, so that it can be passed on unchanged.
+type UnknownContent struct {
+	// Content type
+	Type string `json:"-"`
+
+	// Raw JSON of the content
+	Raw json.RawMessage `json:"-"`
+}
+
+func (UnknownContent) isContent() {}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (c UnknownContent) MarshalJSON() ([]byte, error) {
+	if len(c.Raw) == 0 {
+		return json.Marshal(map[string]string{"type": c.Type})
+	}
+	return c.Raw, nil
+}
+
 // NewTextContent helpe functions for content creation
 func NewTextContent(text string) TextContent {
 	return TextContent{
@@ -234,6 +282,17 @@ func NewAudioContent(data string, mimeType string) AudioContent {
 	}
 }
 
+// NewResourceLink creates a new resource link
+func NewResourceLink(uri string, name string, description string, mimeType string) ResourceLink {
+	return ResourceLink{
+		Type:        ContentTypeResourceLink,
+		URI:         uri,
+		Name:        name,
+		Description: description,
+		MimeType:    mimeType,
+	}
+}
+
 // NewEmbeddedResource creates a new embedded resource
 func NewEmbeddedResource(resource ResourceContents) EmbeddedResource {
 	return EmbeddedResource{
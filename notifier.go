@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sync"
 
 	"trpc.group/trpc-go/trpc-mcp-go/internal/sseutil"
 )
@@ -27,6 +28,9 @@ type sseNotificationSender struct {
 
 	// SSE utility writer
 	sseWriter *sseutil.Writer
+
+	// Prevent concurrent write conflicts
+	writeMu sync.Mutex
 }
 
 // newSSENotificationSender creates an SSE notification sender
@@ -39,28 +43,26 @@ func newSSENotificationSender(w http.ResponseWriter, f http.Flusher, sessionID s
 	}
 }
 
-// SendLogMessage sends a log message notification
-func (s *sseNotificationSender) SendLogMessage(level string, message string) error {
-	return s.SendCustomNotification(NotificationMethodMessage, map[string]interface{}{
+// newLogMessageParams creates the params of a notifications/message sent by SendLogMessage
+func newLogMessageParams(level string, message string) map[string]interface{} {
+	return map[string]interface{}{
 		"level": level,
 		"data": map[string]interface{}{
 			"type":    "log_message",
 			"message": message,
 		},
-	})
+	}
 }
 
-// SendProgress sends a progress update notification
-func (s *sseNotificationSender) SendProgress(progress float64, message string) error {
-	return s.SendCustomNotification(NotificationMethodProgress, map[string]interface{}{
-		"progress": progress,
-		"message":  message,
-		"data": map[string]interface{}{
-			"type":     "process_progress",
-			"progress": progress,
-			"message":  message,
-		},
-	})
+// SendLogMessage sends a log message notification
+func (s *sseNotificationSender) SendLogMessage(level string, message string) error {
+	return s.SendCustomNotification(NotificationMethodMessage, newLogMessageParams(level, message))
+}
+
+// SendProgress does nothing, progress notifications require the progress token of the request.
+// The sender is decorated with the token when the request asks for progress.
+func (s *sseNotificationSender) SendProgress(progress, total float64, message string) error {
+	return nil
 }
 
 // SendCustomNotification sends a custom notification
@@ -86,18 +88,7 @@ func (s *sseNotificationSender) SendCustomNotification(method string, params map
 	// Create jsonNotification
 	jsonNotification := newJSONRPCNotification(notification)
 
-	// Serialize jsonNotification
-	data, err := json.Marshal(jsonNotification)
-	if err != nil {
-		return fmt.Errorf("%w: %v", ErrNotificationSerialization, err)
-	}
-
-	// Send SSE event using sseutil.Writer instead of direct fmt.Fprintf
-	eventID := s.sseWriter.GenerateEventID()
-	return s.sseWriter.WriteEvent(s.writer, sseutil.Event{
-		ID:   eventID,
-		Data: data,
-	})
+	return s.sendMessage(jsonNotification)
 }
 
 // SendNotification sends a custom notification
@@ -105,12 +96,20 @@ func (s *sseNotificationSender) SendNotification(notification *Notification) err
 	// Create notification
 	jsonNotification := newJSONRPCNotification(*notification)
 
-	// Serialize notifications
-	data, err := json.Marshal(jsonNotification)
+	return s.sendMessage(jsonNotification)
+}
+
+// sendMessage writes a JSON-RPC message (notification or server-initiated request) as an SSE event
+func (s *sseNotificationSender) sendMessage(message interface{}) error {
+	// Serialize message
+	data, err := json.Marshal(message)
 	if err != nil {
 		return fmt.Errorf("%w: %v", ErrNotificationSerialization, err)
 	}
 
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
 	// Send SSE event using sseutil.Writer instead of direct fmt.Fprintf
 	eventID := s.sseWriter.GenerateEventID()
 	return s.sseWriter.WriteEvent(s.writer, sseutil.Event{
@@ -128,7 +127,7 @@ func (n *noopNotificationSender) SendLogMessage(level string, message string) er
 }
 
 // SendProgress no-op implementation
-func (n *noopNotificationSender) SendProgress(progress float64, message string) error {
+func (n *noopNotificationSender) SendProgress(progress, total float64, message string) error {
 	return nil
 }
 
@@ -0,0 +1,203 @@
+// Tencent is pleased to support the open source community by making trpc-mcp-go available.
+//
+// Copyright (C) 2025 Tencent.  All rights reserved.
+//
+// trpc-mcp-go is licensed under the Apache License Version 2.0.
+
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// Common errors for server-to-client requests
+var (
+	// ErrClientRequestNotSupported is returned when no channel back to the client is available in the context
+	ErrClientRequestNotSupported = errors.New("server-to-client requests are not supported in this context")
+
+	// ErrClientCapabilityNotSupported is returned when the client did not advertise the required capability
+	ErrClientCapabilityNotSupported = errors.New("client does not support the requested capability")
+)
+
+// Client request sender context key
+const clientRequestSenderKey contextKey = "clientRequestSender"
+
+// clientRequestSender defines the interface for sending requests from the server to the client
+type clientRequestSender interface {
+	// sendClientRequest sends a request to the client and waits for the raw JSON-RPC response message
+	sendClientRequest(ctx context.Context, method string, params interface{}) (json.RawMessage, error)
+}
+
+// withClientRequestSender adds a client request sender to the context
+func withClientRequestSender(ctx context.Context, sender clientRequestSender) context.Context {
+	return context.WithValue(ctx, clientRequestSenderKey, sender)
+}
+
+// getClientRequestSender retrieves the client request sender from the context
+func getClientRequestSender(ctx context.Context) (clientRequestSender, bool) {
+	sender, ok := ctx.Value(clientRequestSenderKey).(clientRequestSender)
+	return sender, ok
+}
+
+// requestClient sends a request to the client associated with ctx and decodes the result into result.
+func requestClient(ctx context.Context, method string, params interface{}, result interface{}) error {
+	sender, ok := getClientRequestSender(ctx)
+	if !ok {
+		return ErrClientRequestNotSupported
+	}
+
+	rawResp, err := sender.sendClientRequest(ctx, method, params)
+	if err != nil {
+		return err
+	}
+
+	msg, msgType, err := parseJSONRPCMessage(rawResp)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrResponseParsing, err)
+	}
+
+	switch msgType {
+	case JSONRPCMessageTypeError:
+		errResp := msg.(*JSONRPCError)
+		return fmt.Errorf("%s error: %s (code: %d)", method, errResp.Error.Message, errResp.Error.Code)
+	case JSONRPCMessageTypeResponse:
+		resp := msg.(*JSONRPCResponse)
+		if result == nil {
+			return nil
+		}
+		resultBytes, err := json.Marshal(resp.Result)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrResponseSerialization, err)
+		}
+		if err := json.Unmarshal(resultBytes, result); err != nil {
+			return fmt.Errorf("%w: %v", ErrResponseParsing, err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: unexpected message type %s", ErrInvalidResponseType, msgType)
+	}
+}
+
+// pendingClientRequest represents a server-to-client request waiting for a response
+type pendingClientRequest struct {
+	// Session that the request was sent to
+	sessionID string
+
+	// Channel receiving the raw response message
+	ch chan json.RawMessage
+}
+
+// clientResponseRouter correlates responses posted by clients with server-initiated requests
+type clientResponseRouter struct {
+	// Request ID generator
+	nextID atomic.Int64
+
+	// Pending requests keyed by request ID
+	pending map[string]*pendingClientRequest
+
+	// Mutex for pending map
+	mu sync.Mutex
+}
+
+// newClientResponseRouter creates a client response router
+func newClientResponseRouter() *clientResponseRouter {
+	return &clientResponseRouter{
+		pending: make(map[string]*pendingClientRequest),
+	}
+}
+
+// register allocates a request ID for the session and returns the channel on which the response is delivered
+func (r *clientResponseRouter) register(sessionID string) (int64, <-chan json.RawMessage) {
+	id := r.nextID.Add(1)
+	ch := make(chan json.RawMessage, 1)
+
+	r.mu.Lock()
+	r.pending[requestIDKey(id)] = &pendingClientRequest{
+		sessionID: sessionID,
+		ch:        ch,
+	}
+	r.mu.Unlock()
+
+	return id, ch
+}
+
+// unregister removes a pending request
+func (r *clientResponseRouter) unregister(id int64) {
+	r.mu.Lock()
+	delete(r.pending, requestIDKey(id))
+	r.mu.Unlock()
+}
+
+// deliver routes a response to the pending request, returns false if no matching request exists
+func (r *clientResponseRouter) deliver(sessionID string, id interface{}, rawMessage json.RawMessage) bool {
+	key := requestIDKey(id)
+
+	r.mu.Lock()
+	pending, ok := r.pending[key]
+	if ok && pending.sessionID != "" && sessionID != "" && pending.sessionID != sessionID {
+		// Response posted from a different session, ignore it
+		ok = false
+	}
+	if ok {
+		delete(r.pending, key)
+	}
+	r.mu.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	pending.ch <- rawMessage
+	return true
+}
+
+// requestIDKey normalizes a JSON-RPC request ID into a map key.
+// Numeric IDs decoded from JSON arrive as float64 and must map to the same key as the original integer.
+func requestIDKey(id interface{}) string {
+	switch v := id.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case json.Number:
+		return v.String()
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// sendAndWait sends a request using send and waits for the correlated response
+func (r *clientResponseRouter) sendAndWait(
+	ctx context.Context,
+	sessionID string,
+	method string,
+	params interface{},
+	send func(req *JSONRPCRequest) error,
+) (json.RawMessage, error) {
+	id, ch := r.register(sessionID)
+	defer r.unregister(id)
+
+	req := &JSONRPCRequest{
+		JSONRPC: JSONRPCVersion,
+		ID:      id,
+		Request: Request{
+			Method: method,
+		},
+		Params: params,
+	}
+
+	if err := send(req); err != nil {
+		return nil, err
+	}
+
+	select {
+	case rawMessage := <-ch:
+		return rawMessage, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -57,8 +57,26 @@ type serverConfig struct {
 	// Tool list filter function
 	toolListFilter ToolListFilter
 
+	// Whether tool call arguments are validated against the input schema of tools
+	toolInputValidation bool
+
+	// Maximum number of items per page of list requests, zero disables pagination
+	pageSize int
+
+	// Maximum number of messages of a JSON-RPC batch processed concurrently
+	batchConcurrency int
+
 	// Method name modifier for external customization.
 	methodNameModifier MethodNameModifier
+
+	// Callback invoked when a client reports a change of its roots
+	rootsListChangedHandler RootsListChangedHandler
+
+	// Middlewares wrapping the handling of requests and notifications
+	middlewares []ServerMiddleware
+
+	// OAuth authorization of requests, nil if requests are not authorized
+	authorization *authorizationConfig
 }
 
 // Server MCP server
@@ -67,6 +85,7 @@ type Server struct {
 	config          *serverConfig      // Configuration.
 	logger          Logger             // Logger for the server and subcomponents.
 	httpHandler     *httpServerHandler // HTTP handler.
+	handler         http.Handler       // HTTP handler with authorization applied.
 	mcpHandler      *mcpHandler        // MCP handler.
 	toolManager     *toolManager       // Tool manager.
 	resourceManager *resourceManager   // Resource manager.
@@ -85,6 +104,7 @@ func NewServer(name, version string, options ...ServerOption) *Server {
 		postSSEEnabled:         true,
 		getSSEEnabled:          true,
 		notificationBufferSize: defaultNotificationBufferSize,
+		toolInputValidation:    true,
 	}
 
 	// Create server with provided serverInfo
@@ -122,12 +142,28 @@ func (s *Server) initComponents() {
 		s.toolManager.withMethodNameModifier(s.config.methodNameModifier)
 	}
 
+	// Set whether tool call arguments are validated.
+	s.toolManager.withInputValidation(s.config.toolInputValidation)
+
 	// Create resource manager.
 	s.resourceManager = newResourceManager()
 
 	// Create prompt manager.
 	s.promptManager = newPromptManager()
 
+	// Notify initialized sessions when the registered tools, resources or prompts change.
+	listChangedNotifier := newListChangedNotifier(defaultListChangedDebounce, s.broadcastListChanged)
+	s.toolManager.withListChangedNotifier(listChangedNotifier)
+	s.resourceManager.withListChangedNotifier(listChangedNotifier)
+	s.promptManager.withListChangedNotifier(listChangedNotifier)
+
+	// Set page size of list requests if configured.
+	if s.config.pageSize > 0 {
+		s.toolManager.withPageSize(s.config.pageSize)
+		s.resourceManager.withPageSize(s.config.pageSize)
+		s.promptManager.withPageSize(s.config.pageSize)
+	}
+
 	// Create lifecycle manager, inject logger if provided.
 	var lifecycleManager *lifecycleManager
 	if s.logger != nil {
@@ -147,6 +183,8 @@ func (s *Server) initComponents() {
 		withLifecycleManager(lifecycleManager),
 		withResourceManager(s.resourceManager),
 		withPromptManager(s.promptManager),
+		withRootsListChangedHandler(s.config.rootsListChangedHandler),
+		withMiddlewares(s.config.middlewares...),
 	)
 
 	// Collect HTTP handler options.
@@ -169,6 +207,7 @@ func (s *Server) initComponents() {
 		withServerPOSTSSEEnabled(s.config.postSSEEnabled),
 		withTransportGetSSEEnabled(s.config.getSSEEnabled),
 		withTransportNotificationBufferSize(s.config.notificationBufferSize),
+		withTransportBatchConcurrency(s.config.batchConcurrency),
 	)
 
 	// HTTP context functions configuration.
@@ -184,6 +223,13 @@ func (s *Server) initComponents() {
 
 	// Create HTTP handler.
 	s.httpHandler = newHTTPServerHandler(s.mcpHandler, s.config.path, httpOptions...)
+	s.handler = s.httpHandler
+
+	// Authorize requests and serve the protected resource metadata if configured.
+	if s.config.authorization != nil {
+		s.handler = newAuthorizationHandler(s.config.authorization, s.httpHandler,
+			s.config.path, s.serverInfo.Name, s.logger)
+	}
 
 	// Set server instance as the tool manager's server provider.
 	s.toolManager.withServerProvider(s)
@@ -245,6 +291,33 @@ func WithHTTPContextFunc(fn HTTPContextFunc) ServerOption {
 	}
 }
 
+// WithAuthorization makes the server an OAuth 2.1 resource server. Requests must carry an
+// "Authorization: Bearer" token accepted by the verifier and issued for the resource URL set with
+// WithAuthResourceURL, or one of the audiences set with WithAuthAudiences, and are otherwise
+// rejected with 401 and a WWW-Authenticate header pointing at the protected resource metadata
+// document served at /.well-known/oauth-protected-resource. Requests lacking the scopes of their
+// methods or tools are rejected with 403, and sessions are bound to the subject of the token they
+// were created with. The information of verified tokens is available to handlers through
+// GetAuthInfoFromContext.
+//
+// Example:
+//
+//	verifier, err := mcp.NewJWTVerifier("jwks.json", mcp.WithJWTIssuer("https://auth.example.com"))
+//	server := mcp.NewServer("server", "1.0.0", mcp.WithAuthorization(verifier,
+//	    mcp.WithAuthResourceURL("https://mcp.example.com/mcp"),
+//	    mcp.WithAuthServers("https://auth.example.com"),
+//	    mcp.WithAuthToolScopes("delete_file", "files:write"),
+//	))
+func WithAuthorization(verifier TokenVerifier, opts ...AuthorizationOption) ServerOption {
+	return func(s *Server) {
+		config := &authorizationConfig{verifier: verifier}
+		for _, opt := range opts {
+			opt(config)
+		}
+		s.config.authorization = config
+	}
+}
+
 // WithStatelessMode sets whether the server uses stateless mode
 // In stateless mode, the server won't generate session IDs and won't validate session IDs in client requests
 // Each request will use a temporary session, which is only valid during request processing
@@ -280,6 +353,52 @@ func WithToolListFilter(filter ToolListFilter) ServerOption {
 	}
 }
 
+// WithToolInputValidation sets whether tool call arguments are validated against the input schema
+// of tools before invoking their handlers, which is enabled by default. Calls with mismatching
+// arguments are rejected with an invalid params error listing the invalid arguments in its data.
+// Validation can also be disabled for a single tool with WithoutInputValidation.
+func WithToolInputValidation(enabled bool) ServerOption {
+	return func(s *Server) {
+		s.config.toolInputValidation = enabled
+	}
+}
+
+// WithMiddleware adds middlewares wrapping the handling of every request and notification.
+// Middlewares run in the order they are added, the first one being the outermost.
+func WithMiddleware(middlewares ...ServerMiddleware) ServerOption {
+	return func(s *Server) {
+		s.config.middlewares = append(s.config.middlewares, middlewares...)
+	}
+}
+
+// WithPageSize sets the maximum number of items returned per page by tools/list, resources/list,
+// resources/templates/list and prompts/list. Items are listed in registration order and further
+// pages are fetched with the opaque nextCursor of the previous page. By default all items are
+// returned in a single page.
+func WithPageSize(pageSize int) ServerOption {
+	return func(s *Server) {
+		s.config.pageSize = pageSize
+	}
+}
+
+// WithBatchConcurrency sets the maximum number of requests and notifications of a JSON-RPC batch
+// processed concurrently. Batches are rejected in sessions negotiating protocol version 2025-06-18
+// or newer, which removed batching.
+func WithBatchConcurrency(concurrency int) ServerOption {
+	return func(s *Server) {
+		s.config.batchConcurrency = concurrency
+	}
+}
+
+// WithRootsListChangedHandler sets a callback invoked when a client sends notifications/roots/list_changed.
+// The callback receives a context bound to the client session, so ListRoots can be used to fetch the new roots.
+// Fetching roots outside of a request requires the client to keep a GET SSE connection open.
+func WithRootsListChangedHandler(handler RootsListChangedHandler) ServerOption {
+	return func(s *Server) {
+		s.config.rootsListChangedHandler = handler
+	}
+}
+
 // WithServerAddress sets the server address
 func WithServerAddress(addr string) ServerOption {
 	return func(s *Server) {
@@ -315,6 +434,46 @@ func (s *Server) UnregisterTools(names ...string) error {
 	return nil
 }
 
+// UnregisterPrompts removes multiple prompts by names and returns an error if no prompts were unregistered
+func (s *Server) UnregisterPrompts(names ...string) error {
+	return unregisterNamed("prompt", names, s.promptManager.unregisterPrompts)
+}
+
+// UnregisterResources removes multiple resources by URIs and returns an error if no resources were unregistered,
+// subscriptions to the removed resources are dropped
+func (s *Server) UnregisterResources(uris ...string) error {
+	return unregisterNamed("resource", uris, s.resourceManager.unregisterResources)
+}
+
+// UnregisterResourceTemplates removes multiple resource templates by names and returns an error
+// if no templates were unregistered
+func (s *Server) UnregisterResourceTemplates(names ...string) error {
+	return unregisterNamed("resource template", names, s.resourceManager.unregisterTemplates)
+}
+
+// ReplaceTools atomically replaces all registered tools with the given ones, listed in the given order,
+// nothing is changed if a tool is invalid or two tools share a name
+func (s *Server) ReplaceTools(tools []ServerTool) error {
+	return s.toolManager.replaceTools(tools)
+}
+
+// ReplacePrompts atomically replaces all registered prompts with the given ones, listed in the given order,
+// nothing is changed if a prompt is invalid or two prompts share a name
+func (s *Server) ReplacePrompts(prompts []ServerPrompt) error {
+	return s.promptManager.replacePrompts(prompts)
+}
+
+// unregisterNamed removes the named items with unregister and reports an error if none was removed
+func unregisterNamed(kind string, names []string, unregister func(names ...string) int) error {
+	if len(names) == 0 {
+		return fmt.Errorf("no %s names provided", kind)
+	}
+	if unregister(names...) == 0 {
+		return fmt.Errorf("none of the specified %ss were found", kind)
+	}
+	return nil
+}
+
 // RegisterResource registers a resource with its handler function
 func (s *Server) RegisterResource(resource *Resource, handler resourceHandler) {
 	s.resourceManager.registerResource(resource, handler)
@@ -427,6 +586,51 @@ func (s *Server) SendFilteredNotification(
 	return successCount, failedCount, nil
 }
 
+// NotifyResourceUpdated sends notifications/resources/updated to the sessions subscribed to the resource.
+// Notifications are delivered over the GET SSE connection of each session, subscriptions of
+// sessions that have expired are dropped. It returns an error if sending to any session failed.
+func (s *Server) NotifyResourceUpdated(uri string) error {
+	if s.config.isStateless {
+		return ErrStatelessMode
+	}
+
+	notification := newResourceUpdatedNotification(uri)
+	var sessions []string
+	for _, sessionID := range s.resourceManager.getSubscribers(uri) {
+		if s.httpHandler.sessionManager != nil {
+			if _, ok := s.httpHandler.sessionManager.getSession(sessionID); !ok {
+				s.resourceManager.unsubscribeSession(sessionID)
+				continue
+			}
+		}
+		sessions = append(sessions, sessionID)
+	}
+
+	_, failedCount, lastError := s.sendNotificationToSessions(sessions, notification)
+	if failedCount > 0 {
+		return fmt.Errorf("failed to notify %d of %d subscribed sessions: %w", failedCount, len(sessions), lastError)
+	}
+	return nil
+}
+
+// broadcastListChanged sends a list_changed notification to all initialized sessions.
+// Sessions without a GET SSE connection cannot receive it and are skipped.
+func (s *Server) broadcastListChanged(method string) {
+	if s.config.isStateless || s.httpHandler == nil {
+		return
+	}
+
+	notification := NewJSONRPCNotificationFromMap(method, nil)
+	for _, sessionID := range s.httpHandler.getActiveSessions() {
+		if !s.mcpHandler.lifecycleManager.isInitialized(sessionID) {
+			continue
+		}
+		if err := s.httpHandler.sendNotification(sessionID, notification); err != nil && s.logger != nil {
+			s.logger.Debugf("Failed to send %s to session %s: %v", method, sessionID, err)
+		}
+	}
+}
+
 // getActiveSessions gets all active session IDs
 func (s *Server) getActiveSessions() ([]string, error) {
 	// Check if in stateless mode
@@ -447,7 +651,7 @@ func (s *Server) GetActiveSessions() ([]string, error) {
 // Handler  returns the http.Handler for the server.
 // This can be used to integrate the MCP server into existing HTTP servers.
 func (s *Server) Handler() http.Handler {
-	return s.httpHandler
+	return s.handler
 }
 
 func (s *Server) Path() string {
@@ -468,7 +672,7 @@ func (s *Server) MCPHandler() requestHandler {
 
 // HTTPHandler returns the HTTP handler
 func (s *Server) HTTPHandler() http.Handler {
-	return s.httpHandler
+	return s.handler
 }
 
 // WithContext enriches a context with server-specific information
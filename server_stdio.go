@@ -28,7 +28,9 @@ type StdioServer struct {
 	resourceManager  *resourceManager
 	promptManager    *promptManager
 	lifecycleManager *lifecycleManager
+	mcpHandler       *mcpHandler
 	internal         messageHandler
+	session          *stdioSession
 }
 
 // messageHandler defines the core interface for handling JSON-RPC messages (internal use).
@@ -42,8 +44,13 @@ type messageHandler interface {
 
 // stdioServerConfig contains configuration for the STDIO server.
 type stdioServerConfig struct {
-	logger      Logger
-	contextFunc StdioContextFunc
+	logger             Logger
+	contextFunc        StdioContextFunc
+	pageSize           int
+	toolListFilter     ToolListFilter
+	methodNameModifier MethodNameModifier
+	inputValidation    bool
+	middlewares        []ServerMiddleware
 }
 
 // StdioServerOption defines an option function for configuring StdioServer.
@@ -56,6 +63,41 @@ func WithStdioServerLogger(logger Logger) StdioServerOption {
 	}
 }
 
+// WithStdioPageSize sets the maximum number of items returned per page by list requests.
+func WithStdioPageSize(pageSize int) StdioServerOption {
+	return func(config *stdioServerConfig) {
+		config.pageSize = pageSize
+	}
+}
+
+// WithStdioToolListFilter sets a tool list filter that will be applied to tools/list requests.
+func WithStdioToolListFilter(filter ToolListFilter) StdioServerOption {
+	return func(config *stdioServerConfig) {
+		config.toolListFilter = filter
+	}
+}
+
+// WithStdioToolInputValidation sets whether tool call arguments are validated against the input schema of tools.
+func WithStdioToolInputValidation(enabled bool) StdioServerOption {
+	return func(config *stdioServerConfig) {
+		config.inputValidation = enabled
+	}
+}
+
+// WithStdioMiddleware adds middlewares wrapping the handling of every request and notification.
+func WithStdioMiddleware(middlewares ...ServerMiddleware) StdioServerOption {
+	return func(config *stdioServerConfig) {
+		config.middlewares = append(config.middlewares, middlewares...)
+	}
+}
+
+// WithStdioMethodNameModifier sets a method name modifier for external customization, e.g. monitoring.
+func WithStdioMethodNameModifier(modifier MethodNameModifier) StdioServerOption {
+	return func(config *stdioServerConfig) {
+		config.methodNameModifier = modifier
+	}
+}
+
 // WithStdioContext sets a context function for the STDIO server.
 func WithStdioContext(fn StdioContextFunc) StdioServerOption {
 	return func(config *stdioServerConfig) {
@@ -69,8 +111,9 @@ type StdioContextFunc func(ctx context.Context) context.Context
 // NewStdioServer creates a new high-level STDIO server that reuses existing managers.
 func NewStdioServer(name, version string, options ...StdioServerOption) *StdioServer {
 	config := &stdioServerConfig{
-		logger:      GetDefaultLogger(),
-		contextFunc: nil,
+		logger:          GetDefaultLogger(),
+		contextFunc:     nil,
+		inputValidation: true,
 	}
 
 	for _, option := range options {
@@ -78,20 +121,29 @@ func NewStdioServer(name, version string, options ...StdioServerOption) *StdioSe
 	}
 
 	// Create reusable managers (same as HTTP server).
-	toolManager := newToolManager()
-	resourceManager := newResourceManager()
-	promptManager := newPromptManager()
+	toolManager := newToolManager().
+		withPageSize(config.pageSize).
+		withToolListFilter(config.toolListFilter).
+		withMethodNameModifier(config.methodNameModifier).
+		withInputValidation(config.inputValidation)
+	resourceManager := newResourceManager().withPageSize(config.pageSize)
+	promptManager := newPromptManager().withPageSize(config.pageSize)
 	lifecycleManager := newLifecycleManager(Implementation{
 		Name:    name,
 		Version: version,
 	})
 
-	// Set up manager relationships.
-	lifecycleManager.withToolManager(toolManager)
-	lifecycleManager.withResourceManager(resourceManager)
-	lifecycleManager.withPromptManager(promptManager)
 	lifecycleManager.withLogger(config.logger)
 
+	// Requests are dispatched by the same MCP handler as the HTTP servers.
+	mcpHandler := newMCPHandler(
+		withToolManager(toolManager),
+		withLifecycleManager(lifecycleManager),
+		withResourceManager(resourceManager),
+		withPromptManager(promptManager),
+		withMiddlewares(config.middlewares...),
+	)
+
 	server := &StdioServer{
 		serverInfo: Implementation{
 			Name:    name,
@@ -103,12 +155,20 @@ func NewStdioServer(name, version string, options ...StdioServerOption) *StdioSe
 		resourceManager:  resourceManager,
 		promptManager:    promptManager,
 		lifecycleManager: lifecycleManager,
+		mcpHandler:       mcpHandler,
+		session:          newStdioSession(),
 	}
 
 	server.internal = &stdioServerInternal{
 		parent: server,
 	}
 
+	// Notify the client when the registered tools, resources or prompts change.
+	listChangedNotifier := newListChangedNotifier(defaultListChangedDebounce, server.sendListChanged)
+	toolManager.withListChangedNotifier(listChangedNotifier)
+	resourceManager.withListChangedNotifier(listChangedNotifier)
+	promptManager.withListChangedNotifier(listChangedNotifier)
+
 	return server
 }
 
@@ -138,6 +198,35 @@ func (s *StdioServer) UnregisterTools(names ...string) error {
 	return nil
 }
 
+// UnregisterPrompts removes multiple prompts by names and returns an error if no prompts were unregistered.
+func (s *StdioServer) UnregisterPrompts(names ...string) error {
+	return unregisterNamed("prompt", names, s.promptManager.unregisterPrompts)
+}
+
+// UnregisterResources removes multiple resources by URIs and returns an error if no resources were unregistered.
+// Subscriptions to the removed resources are dropped.
+func (s *StdioServer) UnregisterResources(uris ...string) error {
+	return unregisterNamed("resource", uris, s.resourceManager.unregisterResources)
+}
+
+// UnregisterResourceTemplates removes multiple resource templates by names and returns an error
+// if no templates were unregistered.
+func (s *StdioServer) UnregisterResourceTemplates(names ...string) error {
+	return unregisterNamed("resource template", names, s.resourceManager.unregisterTemplates)
+}
+
+// ReplaceTools atomically replaces all registered tools with the given ones, listed in the given order.
+// Nothing is changed if a tool is invalid or two tools share a name.
+func (s *StdioServer) ReplaceTools(tools []ServerTool) error {
+	return s.toolManager.replaceTools(tools)
+}
+
+// ReplacePrompts atomically replaces all registered prompts with the given ones, listed in the given order.
+// Nothing is changed if a prompt is invalid or two prompts share a name.
+func (s *StdioServer) ReplacePrompts(prompts []ServerPrompt) error {
+	return s.promptManager.replacePrompts(prompts)
+}
+
 // RegisterPrompt registers a prompt with its handler using the prompt manager.
 func (s *StdioServer) RegisterPrompt(prompt *Prompt, handler promptHandler) {
 	if prompt == nil || handler == nil {
@@ -168,14 +257,55 @@ func (s *StdioServer) RegisterResourceTemplate(template *ResourceTemplate, handl
 	s.logger.Debugf("Registered resource template: %s", template.Name)
 }
 
+// SendNotification sends a notification to the client.
+// Notifications sent outside of request handlers are written to stdout asynchronously.
+func (s *StdioServer) SendNotification(method string, params map[string]interface{}) error {
+	return s.enqueueNotification(NewJSONRPCNotificationFromMap(method, params))
+}
+
+// NotifyResourceUpdated sends notifications/resources/updated to the client if it subscribed to the resource.
+func (s *StdioServer) NotifyResourceUpdated(uri string) error {
+	for _, sessionID := range s.resourceManager.getSubscribers(uri) {
+		if sessionID != s.session.GetID() {
+			continue
+		}
+		if err := s.enqueueNotification(newResourceUpdatedNotification(uri)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendListChanged sends a list_changed notification to the client once it is initialized.
+func (s *StdioServer) sendListChanged(method string) {
+	if !s.session.Initialized() {
+		return
+	}
+	if err := s.enqueueNotification(NewJSONRPCNotificationFromMap(method, nil)); err != nil {
+		s.logger.Debugf("Failed to send %s: %v", method, err)
+	}
+}
+
+// enqueueNotification queues a notification for the transport writing it to stdout.
+func (s *StdioServer) enqueueNotification(notification *JSONRPCNotification) error {
+	select {
+	case s.session.notifications <- *notification:
+		return nil
+	default:
+		return fmt.Errorf("notification channel full")
+	}
+}
+
 // Start starts the STDIO server.
 func (s *StdioServer) Start() error {
-	return serveStdio(s.internal, withStdioErrorLogger(s.logger), withStdioContextFunc(s.contextFunc))
+	return serveStdio(s.internal, withStdioErrorLogger(s.logger), withStdioContextFunc(s.contextFunc),
+		withStdioSession(s.session))
 }
 
 // StartWithContext starts the STDIO server with context.
 func (s *StdioServer) StartWithContext(ctx context.Context) error {
-	return serveStdioWithContext(ctx, s.internal, withStdioErrorLogger(s.logger), withStdioContextFunc(s.contextFunc))
+	return serveStdioWithContext(ctx, s.internal, withStdioErrorLogger(s.logger), withStdioContextFunc(s.contextFunc),
+		withStdioSession(s.session))
 }
 
 // GetServerInfo returns the server information.
@@ -189,6 +319,8 @@ type stdioTransport struct {
 	logger      Logger
 	contextFunc StdioContextFunc
 	session     *stdioSession
+	writeMu     sync.Mutex     // Serializes writes of responses and notifications.
+	requests    sync.WaitGroup // Requests being processed concurrently.
 }
 
 // stdioServerTransportOption configures a stdioTransport.
@@ -208,6 +340,13 @@ func withStdioContextFunc(fn StdioContextFunc) stdioServerTransportOption {
 	}
 }
 
+// withStdioSession sets the session of the transport.
+func withStdioSession(session *stdioSession) stdioServerTransportOption {
+	return func(s *stdioTransport) {
+		s.session = session
+	}
+}
+
 // stdioSession represents a stdio session implementing the Session interface.
 type stdioSession struct {
 	id            string
@@ -219,6 +358,18 @@ type stdioSession struct {
 	mu            sync.RWMutex
 }
 
+// newStdioSession creates the session of a stdio connection.
+func newStdioSession() *stdioSession {
+	now := time.Now()
+	return &stdioSession{
+		id:            "stdio",
+		createdAt:     now,
+		lastActivity:  now,
+		data:          make(map[string]interface{}),
+		notifications: make(chan JSONRPCNotification, 100),
+	}
+}
+
 func (s *stdioSession) getID() string {
 	return s.id
 }
@@ -298,17 +449,10 @@ func (s *stdioSession) Initialized() bool {
 
 // newStdioTransport creates a new stdio transport.
 func newStdioTransport(server messageHandler, options ...stdioServerTransportOption) *stdioTransport {
-	now := time.Now()
 	transport := &stdioTransport{
-		server: server,
-		logger: GetDefaultLogger(),
-		session: &stdioSession{
-			id:            "stdio",
-			createdAt:     now,
-			lastActivity:  now,
-			data:          make(map[string]interface{}),
-			notifications: make(chan JSONRPCNotification, 100),
-		},
+		server:  server,
+		logger:  GetDefaultLogger(),
+		session: newStdioSession(),
 	}
 
 	for _, option := range options {
@@ -346,6 +490,9 @@ func (s *stdioTransport) handleNotifications(ctx context.Context, stdout io.Writ
 
 // processInputStream reads and processes messages from the input stream.
 func (s *stdioTransport) processInputStream(ctx context.Context, reader *bufio.Reader, stdout io.Writer) error {
+	// Wait for pending requests so their responses are written before returning.
+	defer s.requests.Wait()
+
 	for {
 		if err := ctx.Err(); err != nil {
 			return err
@@ -425,17 +572,29 @@ func (s *stdioTransport) processMessage(ctx context.Context, line string, writer
 	}
 
 	sessionCtx := context.WithValue(ctx, sessionKey{}, s.session)
+	sessionCtx = setSessionToContext(sessionCtx, s.session)
+
+	// Allow handlers to send notifications, e.g. progress and log messages, to the client.
+	sessionCtx = withNotificationSender(sessionCtx, &stdioNotificationSender{transport: s, writer: writer})
 
 	switch msgType {
 	case JSONRPCMessageTypeRequest:
-		response, err := s.server.HandleRequest(sessionCtx, rawMessage)
-		if err != nil {
-			s.logger.Errorf("Error handling request: %v", err)
-			return nil
-		}
-		if response != nil {
-			return s.writeResponse(response, writer)
-		}
+		// Requests are processed concurrently so that notifications such as
+		// notifications/cancelled can be read while a request is running.
+		s.requests.Add(1)
+		go func() {
+			defer s.requests.Done()
+			response, err := s.server.HandleRequest(sessionCtx, rawMessage)
+			if err != nil {
+				s.logger.Errorf("Error handling request: %v", err)
+				return
+			}
+			if response != nil {
+				if err := s.writeResponse(response, writer); err != nil {
+					s.logger.Errorf("Error writing response: %v", err)
+				}
+			}
+		}()
 
 	case JSONRPCMessageTypeNotification:
 		if err := s.server.HandleNotification(sessionCtx, rawMessage); err != nil {
@@ -453,6 +612,9 @@ func (s *stdioTransport) writeResponse(response interface{}, writer io.Writer) e
 		return fmt.Errorf("error marshaling response: %w", err)
 	}
 
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
 	if _, err := writer.Write(data); err != nil {
 		return fmt.Errorf("error writing response: %w", err)
 	}
@@ -464,6 +626,35 @@ func (s *stdioTransport) writeResponse(response interface{}, writer io.Writer) e
 	return nil
 }
 
+// stdioNotificationSender sends notifications of request handlers to the client over stdout.
+// Notifications are written directly rather than queued, so they are delivered before the response
+// of the request sending them.
+type stdioNotificationSender struct {
+	transport *stdioTransport
+	writer    io.Writer
+}
+
+// SendLogMessage sends a log message notification.
+func (n *stdioNotificationSender) SendLogMessage(level string, message string) error {
+	return n.SendCustomNotification(NotificationMethodMessage, newLogMessageParams(level, message))
+}
+
+// SendProgress does nothing, progress notifications require the progress token of the request.
+// The sender is decorated with the token when the request asks for progress.
+func (n *stdioNotificationSender) SendProgress(progress, total float64, message string) error {
+	return nil
+}
+
+// SendCustomNotification sends a custom notification.
+func (n *stdioNotificationSender) SendCustomNotification(method string, params map[string]interface{}) error {
+	return n.transport.writeResponse(NewJSONRPCNotificationFromMap(method, params), n.writer)
+}
+
+// SendNotification sends a notification.
+func (n *stdioNotificationSender) SendNotification(notification *Notification) error {
+	return n.transport.writeResponse(newJSONRPCNotification(*notification), n.writer)
+}
+
 // serveStdio is a convenience function to start a stdio server.
 func serveStdio(server messageHandler, options ...stdioServerTransportOption) error {
 	return serveStdioWithContext(context.Background(), server, options...)
@@ -495,44 +686,18 @@ type stdioServerInternal struct {
 	parent *StdioServer
 }
 
-// HandleRequest implements messageHandler.HandleRequest by delegating to existing managers.
+// HandleRequest implements messageHandler.HandleRequest by delegating to the MCP handler.
 func (s *stdioServerInternal) HandleRequest(ctx context.Context, rawMessage json.RawMessage) (interface{}, error) {
 	var request JSONRPCRequest
 	if err := json.Unmarshal(rawMessage, &request); err != nil {
-		return newJSONRPCErrorResponse(nil, -32700, "Parse error", nil), nil
+		return newJSONRPCErrorResponse(nil, ErrCodeParse, "Parse error", nil), nil
 	}
 
 	s.parent.logger.Debugf("Handling request: %s (ID: %v)", request.Method, request.ID)
 
-	// Get session from context for managers that need it.
-	session := sessionFromContext(ctx)
-
-	var result interface{}
-	var err error
-
-	switch request.Method {
-	case MethodInitialize:
-		result, err = s.parent.lifecycleManager.handleInitialize(ctx, &request, session)
-	case MethodToolsList:
-		result, err = s.parent.toolManager.handleListTools(ctx, &request, session)
-	case MethodToolsCall:
-		result, err = s.parent.toolManager.handleCallTool(ctx, &request, session)
-	case MethodPromptsList:
-		result, err = s.parent.promptManager.handleListPrompts(ctx, &request)
-	case MethodPromptsGet:
-		result, err = s.parent.promptManager.handleGetPrompt(ctx, &request)
-	case MethodResourcesList:
-		result, err = s.parent.resourceManager.handleListResources(ctx, &request)
-	case MethodResourcesRead:
-		result, err = s.parent.resourceManager.handleReadResource(ctx, &request)
-	case MethodPing:
-		return s.handlePing(ctx, request)
-	default:
-		return newJSONRPCErrorResponse(request.ID, -32601, "Method not found", nil), nil
-	}
-
+	result, err := s.parent.mcpHandler.handleRequest(ctx, &request, getStdioSession(ctx))
 	if err != nil {
-		return newJSONRPCErrorResponse(request.ID, -32603, "Internal error", err.Error()), nil
+		return newJSONRPCErrorResponse(request.ID, ErrCodeInternal, "Internal error", err.Error()), nil
 	}
 
 	// Check if result is already a JSON-RPC response or error (has jsonrpc field).
@@ -545,7 +710,7 @@ func (s *stdioServerInternal) HandleRequest(ctx context.Context, rawMessage json
 	return newJSONRPCResponse(request.ID, result), nil
 }
 
-// HandleNotification implements messageHandler.HandleNotification.
+// HandleNotification implements messageHandler.HandleNotification by delegating to the MCP handler.
 func (s *stdioServerInternal) HandleNotification(ctx context.Context, rawMessage json.RawMessage) error {
 	var notification JSONRPCNotification
 	if err := json.Unmarshal(rawMessage, &notification); err != nil {
@@ -553,9 +718,22 @@ func (s *stdioServerInternal) HandleNotification(ctx context.Context, rawMessage
 	}
 
 	s.parent.logger.Debugf("Received notification: %s", notification.Method)
+
+	if err := s.parent.mcpHandler.handleNotification(ctx, &notification, getStdioSession(ctx)); err != nil {
+		return err
+	}
+
+	if session := sessionFromContext(ctx); session != nil && notification.Method == MethodNotificationsInitialized {
+		// Server notifications can be pushed once the client has confirmed initialization.
+		session.Initialize()
+	}
 	return nil
 }
 
-func (s *stdioServerInternal) handlePing(ctx context.Context, request JSONRPCRequest) (interface{}, error) {
-	return newJSONRPCResponse(request.ID, struct{}{}), nil
+// getStdioSession gets the stdio session from context as a Session, which is nil without a session
+func getStdioSession(ctx context.Context) Session {
+	if session := sessionFromContext(ctx); session != nil {
+		return session
+	}
+	return nil
 }
@@ -7,10 +7,15 @@
 package mcp
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestStdioServer_UnregisterTools(t *testing.T) {
@@ -102,3 +107,95 @@ func TestStdioServer_RegisterAndUnregisterTool(t *testing.T) {
 	tools = server.toolManager.getTools("")
 	assert.Len(t, tools, 0)
 }
+
+func TestStdioServer_SharedDispatch(t *testing.T) {
+	server := NewStdioServer("Test-Stdio-Server", "1.0.0",
+		WithStdioToolListFilter(func(ctx context.Context, tools []*Tool) []*Tool {
+			return tools[:1]
+		}))
+	handler := func(ctx context.Context, req *CallToolRequest) (*CallToolResult, error) {
+		return NewTextResult("ok"), nil
+	}
+	server.RegisterTool(NewTool("visible"), handler)
+	server.RegisterTool(NewTool("hidden"), handler)
+	server.RegisterPrompt(&Prompt{
+		Name:      "code_review",
+		Arguments: []PromptArgument{{Name: "language", Completion: prefixCompletion("go", "golang", "python")}},
+	}, func(ctx context.Context, req *GetPromptRequest) (*GetPromptResult, error) {
+		return &GetPromptResult{}, nil
+	})
+
+	ctx := context.WithValue(context.Background(), sessionKey{}, server.session)
+	request := func(method string, params string) map[string]interface{} {
+		raw := `{"jsonrpc":"2.0","id":1,"method":"` + method + `","params":` + params + `}`
+		resp, err := server.internal.HandleRequest(ctx, json.RawMessage(raw))
+		require.NoError(t, err)
+		data, err := json.Marshal(resp)
+		require.NoError(t, err)
+		var message map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &message))
+		require.Nil(t, message["error"], "%s failed: %s", method, data)
+		return message["result"].(map[string]interface{})
+	}
+
+	result := request(MethodInitialize, `{"protocolVersion":"2025-06-18","clientInfo":{"name":"c","version":"1"},"capabilities":{}}`)
+	assert.Contains(t, result["capabilities"], "completions")
+	require.NoError(t, server.internal.HandleNotification(ctx,
+		json.RawMessage(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)))
+	assert.True(t, server.session.Initialized())
+	assert.True(t, server.lifecycleManager.isInitialized(server.session.GetID()))
+
+	// Methods previously missing from the stdio server
+	result = request(MethodCompletionComplete,
+		`{"ref":{"type":"ref/prompt","name":"code_review"},"argument":{"name":"language","value":"go"}}`)
+	assert.Equal(t, []interface{}{"go", "golang"}, result["completion"].(map[string]interface{})["values"])
+	request(MethodLoggingSetLevel, `{"level":"debug"}`)
+
+	// Tool list filter
+	result = request(MethodToolsList, `{}`)
+	assert.Len(t, result["tools"], 1)
+}
+
+func TestStdioServer_Notifications(t *testing.T) {
+	server := NewStdioServer("Test-Stdio-Server", "1.0.0")
+	server.RegisterTool(NewTool("slow"), func(ctx context.Context, req *CallToolRequest) (*CallToolResult, error) {
+		sender, ok := GetNotificationSender(ctx)
+		require.True(t, ok)
+		require.NoError(t, sender.SendProgress(0.5, 1, "half"))
+		GetClientLogger(ctx).Info("working")
+		return NewTextResult("done"), nil
+	})
+
+	input := strings.Join([]string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","clientInfo":{"name":"c","version":"1"},"capabilities":{}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"slow","_meta":{"progressToken":"p1"}}}`,
+	}, "\n") + "\n"
+	var output bytes.Buffer
+	transport := newStdioTransport(server.internal, withStdioSession(server.session))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, transport.processInputStream(ctx, bufio.NewReader(strings.NewReader(input)), &output))
+
+	// Notifications of the tool precede its response
+	var methods []string
+	for _, line := range strings.Split(strings.TrimSpace(output.String()), "\n") {
+		var message struct {
+			ID     interface{} `json:"id"`
+			Method string      `json:"method"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(line), &message))
+		if message.ID == float64(2) {
+			methods = append(methods, "response")
+		} else if message.Method != "" {
+			methods = append(methods, message.Method)
+		}
+	}
+	assert.Equal(t, []string{NotificationMethodProgress, NotificationMethodMessage, "response"}, methods)
+	assert.Contains(t, output.String(), `"progressToken":"p1"`)
+
+	// Notifications sent outside of requests are queued for the transport
+	require.NoError(t, server.SendNotification("notifications/custom", map[string]interface{}{"key": "value"}))
+	notification := <-server.session.notifications
+	assert.Equal(t, "notifications/custom", notification.Method)
+}
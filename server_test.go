@@ -7,13 +7,17 @@
 package mcp
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"trpc.group/trpc-go/trpc-mcp-go/internal/httputil"
 )
 
 // Create test server
@@ -356,3 +360,137 @@ func TestServer_UnregisterTools(t *testing.T) {
 	tools = server.toolManager.getTools("")
 	assert.Len(t, tools, 0)
 }
+
+func TestServer_UnregisterPromptsAndResources(t *testing.T) {
+	server := NewServer("Test-Server", "1.0.0")
+	promptHandler := func(ctx context.Context, req *GetPromptRequest) (*GetPromptResult, error) {
+		return &GetPromptResult{}, nil
+	}
+	resourceHandler := func(ctx context.Context, req *ReadResourceRequest) (ResourceContents, error) {
+		return TextResourceContents{URI: req.Params.URI}, nil
+	}
+	templateHandler := func(ctx context.Context, req *ReadResourceRequest) ([]ResourceContents, error) {
+		return nil, nil
+	}
+
+	server.RegisterPrompt(&Prompt{Name: "prompt-1"}, promptHandler)
+	server.RegisterPrompt(&Prompt{Name: "prompt-2"}, promptHandler)
+	server.RegisterResource(&Resource{URI: "file:///a", Name: "a"}, resourceHandler)
+	server.RegisterResourceTemplate(NewResourceTemplate("file:///t/{id}", "template"), templateHandler)
+
+	assert.NoError(t, server.UnregisterPrompts("prompt-1"))
+	assert.Error(t, server.UnregisterPrompts("prompt-1"))
+	assert.Error(t, server.UnregisterPrompts())
+	assert.Len(t, server.promptManager.getPrompts(), 1)
+
+	assert.NoError(t, server.UnregisterResources("file:///a"))
+	assert.Error(t, server.UnregisterResources("file:///a"))
+	assert.Empty(t, server.resourceManager.getResources())
+
+	// Capabilities follow the registry, resources remain advertised while a template is registered
+	server.mcpHandler.lifecycleManager.updateCapabilities()
+	assert.Contains(t, server.mcpHandler.lifecycleManager.capabilities, "resources")
+
+	assert.NoError(t, server.UnregisterResourceTemplates("template"))
+	assert.Error(t, server.UnregisterResourceTemplates("template"))
+	assert.Empty(t, server.resourceManager.getTemplates())
+
+	server.mcpHandler.lifecycleManager.updateCapabilities()
+	assert.NotContains(t, server.mcpHandler.lifecycleManager.capabilities, "resources")
+	assert.Contains(t, server.mcpHandler.lifecycleManager.capabilities, "prompts")
+}
+
+func TestServer_ReplaceToolsAndPrompts(t *testing.T) {
+	server := NewServer("Test-Server", "1.0.0")
+	toolHandler := func(ctx context.Context, req *CallToolRequest) (*CallToolResult, error) {
+		return NewTextResult(""), nil
+	}
+	promptHandler := func(ctx context.Context, req *GetPromptRequest) (*GetPromptResult, error) {
+		return &GetPromptResult{}, nil
+	}
+	server.RegisterTool(NewTool("old"), toolHandler)
+	server.RegisterPrompt(&Prompt{Name: "old"}, promptHandler)
+
+	// The new set is listed in the given order
+	require.NoError(t, server.ReplaceTools([]ServerTool{
+		{Tool: NewTool("b"), Handler: toolHandler},
+		{Tool: NewTool("a"), Handler: toolHandler},
+	}))
+	tools := server.toolManager.getTools("")
+	require.Len(t, tools, 2)
+	assert.Equal(t, "b", tools[0].Name)
+	assert.Equal(t, "a", tools[1].Name)
+
+	// Invalid sets leave the registry unchanged
+	assert.Error(t, server.ReplaceTools([]ServerTool{
+		{Tool: NewTool("c"), Handler: toolHandler},
+		{Tool: NewTool("c"), Handler: toolHandler},
+	}))
+	assert.Error(t, server.ReplaceTools([]ServerTool{{Tool: NewTool("c")}}))
+	assert.Len(t, server.toolManager.getTools(""), 2)
+
+	require.NoError(t, server.ReplacePrompts([]ServerPrompt{{Prompt: &Prompt{Name: "new"}, Handler: promptHandler}}))
+	prompts := server.promptManager.getPrompts()
+	require.Len(t, prompts, 1)
+	assert.Equal(t, "new", prompts[0].Name)
+
+	// Replacing with an empty set removes everything
+	require.NoError(t, server.ReplaceTools(nil))
+	assert.Empty(t, server.toolManager.getTools(""))
+}
+
+func TestServer_ProtocolVersionHeader(t *testing.T) {
+	_, httpServer := createTestServer()
+	defer httpServer.Close()
+	url := httpServer.URL + "/mcp"
+
+	post := func(sessionID, protocolVersion, body string) (int, string) {
+		req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader([]byte(body)))
+		require.NoError(t, err)
+		req.Header.Set(httputil.ContentTypeHeader, httputil.ContentTypeJSON)
+		req.Header.Set(httputil.AcceptHeader, httputil.ContentTypeJSON)
+		if sessionID != "" {
+			req.Header.Set(httputil.SessionIDHeader, sessionID)
+		}
+		if protocolVersion != "" {
+			req.Header.Set(httputil.ProtocolVersionHeader, protocolVersion)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		if id := resp.Header.Get(httputil.SessionIDHeader); id != "" && sessionID == "" {
+			return resp.StatusCode, id
+		}
+		return resp.StatusCode, string(data)
+	}
+	initialize := func(version string) string {
+		status, sessionID := post("", "", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{`+
+			`"protocolVersion":"`+version+`","clientInfo":{"name":"test","version":"1.0"},"capabilities":{}}}`)
+		require.Equal(t, http.StatusOK, status)
+		return sessionID
+	}
+	listTools := `{"jsonrpc":"2.0","id":2,"method":"tools/list"}`
+
+	// Sessions negotiated with 2025-06-18 require a matching header
+	sessionID := initialize(ProtocolVersion_2025_06_18)
+	status, body := post(sessionID, "", listTools)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, body, httputil.ProtocolVersionHeader)
+	status, _ = post(sessionID, ProtocolVersion_2025_03_26, listTools)
+	assert.Equal(t, http.StatusBadRequest, status)
+	status, _ = post(sessionID, "1999-01-01", listTools)
+	assert.Equal(t, http.StatusBadRequest, status)
+	status, _ = post(sessionID, ProtocolVersion_2025_06_18, listTools)
+	assert.Equal(t, http.StatusOK, status)
+
+	// Batching is not allowed in 2025-06-18
+	status, body = post(sessionID, ProtocolVersion_2025_06_18, "["+listTools+"]")
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, body, ErrBatchNotSupported.Error())
+
+	// Older sessions keep working without the header
+	sessionID = initialize(ProtocolVersion_2025_03_26)
+	status, _ = post(sessionID, "", listTools)
+	assert.Equal(t, http.StatusOK, status)
+}
@@ -8,6 +8,7 @@ package mcp
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"trpc.group/trpc-go/trpc-mcp-go/internal/session"
@@ -86,6 +87,17 @@ func newSessionManager(expirySeconds int) sessionManager {
 	}
 }
 
+const (
+	// sessionDataKeyClientCapabilities is the session data key storing the client capabilities
+	sessionDataKeyClientCapabilities = "clientCapabilities"
+
+	// sessionDataKeyProtocolVersion is the session data key storing the negotiated protocol version
+	sessionDataKeyProtocolVersion = "protocolVersion"
+
+	// sessionDataKeyLoggingLevel is the session data key storing the logging level set by the client
+	sessionDataKeyLoggingLevel = "loggingLevel"
+)
+
 // Session context key
 type sessionContextKey struct{}
 
@@ -112,3 +124,55 @@ func setServerToContext(ctx context.Context, server interface{}) context.Context
 func GetServerFromContext(ctx context.Context) interface{} {
 	return ctx.Value(serverContextKey{})
 }
+
+// parseClientCapabilities converts the raw capabilities from an initialize request into ClientCapabilities
+func parseClientCapabilities(raw interface{}) (*ClientCapabilities, error) {
+	if capabilities, ok := raw.(ClientCapabilities); ok {
+		return &capabilities, nil
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	var capabilities ClientCapabilities
+	if err := json.Unmarshal(data, &capabilities); err != nil {
+		return nil, err
+	}
+	return &capabilities, nil
+}
+
+// getSessionProtocolVersion gets the protocol version negotiated during initialization,
+// or an empty string if the session has not been initialized
+func getSessionProtocolVersion(session Session) string {
+	if session == nil {
+		return ""
+	}
+	value, ok := session.GetData(sessionDataKeyProtocolVersion)
+	if !ok {
+		return ""
+	}
+	version, _ := value.(string)
+	return version
+}
+
+// getClientCapabilities gets the capabilities the client advertised during initialization
+func getClientCapabilities(session Session) (*ClientCapabilities, bool) {
+	if session == nil {
+		return nil, false
+	}
+	value, ok := session.GetData(sessionDataKeyClientCapabilities)
+	if !ok {
+		return nil, false
+	}
+	capabilities, ok := value.(*ClientCapabilities)
+	return capabilities, ok
+}
+
+// getClientCapabilitiesFromContext gets the client capabilities of the session stored in the context
+func getClientCapabilitiesFromContext(ctx context.Context) (*ClientCapabilities, bool) {
+	session, ok := GetSessionFromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+	return getClientCapabilities(session)
+}
@@ -40,8 +40,12 @@ type sseClientTransport struct {
 		mutex  sync.Mutex         // Mutex for synchronizing connection operations.
 	}
 
-	onNotification func(notification *JSONRPCNotification) // Notification handler.
-	notificationMu sync.RWMutex                            // Mutex for notification handler.
+	notificationHandlers map[string]NotificationHandler // Notification handlers keyed by method.
+	notificationMu       sync.RWMutex                   // Mutex for notification handlers.
+
+	requestHandlers  *clientRequestHandlers  // Handlers for server-initiated requests.
+	progressHandlers *clientProgressHandlers // Handlers for progress notifications of in-flight requests.
+	interceptors     clientInterceptors      // Interceptors of received notifications.
 
 	started      atomic.Bool   // Flag indicating if transport is started.
 	closed       atomic.Bool   // Flag indicating if transport is closed.
@@ -73,6 +77,9 @@ func NewSSEClient(serverURL string, clientInfo Implementation, options ...Client
 				httpClient:            config.httpClient,
 				httpHeaders:           config.httpHeaders,
 				responses:             make(map[string]chan *json.RawMessage),
+				notificationHandlers:  make(map[string]NotificationHandler),
+				requestHandlers:       newClientRequestHandlers(),
+				progressHandlers:      newClientProgressHandlers(),
 				endpointChan:          make(chan struct{}),
 				logger:                config.logger,
 				serviceName:           config.serviceName,
@@ -272,10 +279,14 @@ func (t *sseClientTransport) handleMessageEvent(data string) {
 		return
 	}
 
-	// Check if the message is a response or a notification.
-	if _, hasID := message["id"]; hasID {
+	// Check if the message is a request, a response or a notification.
+	_, hasID := message["id"]
+	_, hasMethod := message["method"]
+	if hasID && hasMethod {
+		go t.handleServerRequest(json.RawMessage(data))
+	} else if hasID {
 		t.handleResponse(data)
-	} else if _, hasMethod := message["method"]; hasMethod {
+	} else if hasMethod {
 		t.handleNotification(data)
 	} else {
 		if t.logger != nil {
@@ -336,12 +347,82 @@ func (t *sseClientTransport) handleNotification(data string) {
 		return
 	}
 
+	if err := t.interceptors.handleNotification(&notification, t.deliverNotification); err != nil && t.logger != nil {
+		t.logger.Debugf("Error handling notification %s: %v", notification.Method, err)
+	}
+}
+
+// deliverNotification delivers a notification to its handler.
+func (t *sseClientTransport) deliverNotification(notification *JSONRPCNotification) error {
+	// Deliver progress to the handler of the request it belongs to.
+	t.progressHandlers.handle(notification)
+
 	t.notificationMu.RLock()
-	handler := t.onNotification
+	handler, ok := t.notificationHandlers[notification.Method]
 	t.notificationMu.RUnlock()
 
-	if handler != nil {
-		handler(&notification)
+	if ok && handler != nil {
+		return handler(notification)
+	}
+	return nil
+}
+
+// setInterceptors sets the interceptors of received notifications.
+func (t *sseClientTransport) setInterceptors(interceptors clientInterceptors) {
+	t.interceptors = interceptors
+}
+
+// registerNotificationHandler registers a handler for server notifications.
+func (t *sseClientTransport) registerNotificationHandler(method string, handler NotificationHandler) {
+	t.notificationMu.Lock()
+	defer t.notificationMu.Unlock()
+
+	t.notificationHandlers[method] = handler
+}
+
+// unregisterNotificationHandler unregisters a handler for server notifications.
+func (t *sseClientTransport) unregisterNotificationHandler(method string) {
+	t.notificationMu.Lock()
+	defer t.notificationMu.Unlock()
+
+	delete(t.notificationHandlers, method)
+}
+
+// registerRequestHandler registers a handler for server-initiated requests.
+func (t *sseClientTransport) registerRequestHandler(method string, handler RequestHandler) {
+	t.requestHandlers.register(method, handler)
+}
+
+// unregisterRequestHandler unregisters a handler for server-initiated requests.
+func (t *sseClientTransport) unregisterRequestHandler(method string) {
+	t.requestHandlers.unregister(method)
+}
+
+// registerProgressHandler registers a handler for the progress notifications of a request.
+func (t *sseClientTransport) registerProgressHandler(token ProgressToken, handler ProgressHandler) func() {
+	return t.progressHandlers.register(token, handler)
+}
+
+// handleServerRequest dispatches a server-initiated request and posts the response back to the server.
+func (t *sseClientTransport) handleServerRequest(rawMessage json.RawMessage) {
+	var req JSONRPCRequest
+	if err := json.Unmarshal(rawMessage, &req); err != nil {
+		if t.logger != nil {
+			t.logger.Errorf("Error parsing server request: %v", err)
+		}
+		return
+	}
+
+	t.sseConn.mutex.Lock()
+	ctx := t.sseConn.ctx
+	t.sseConn.mutex.Unlock()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	reply := t.requestHandlers.handle(ctx, &req)
+	if err := t.postMessage(ctx, reply); err != nil && t.logger != nil {
+		t.logger.Errorf("Error sending response for server request %s: %v", req.Method, err)
 	}
 }
 
@@ -452,6 +533,11 @@ func (t *sseClientTransport) sendRequest(ctx context.Context, req *JSONRPCReques
 
 // sendNotification sends a notification without expecting a response.
 func (t *sseClientTransport) sendNotification(ctx context.Context, notification *JSONRPCNotification) error {
+	return t.postMessage(ctx, notification)
+}
+
+// postMessage posts a notification or response to the message endpoint without expecting a response.
+func (t *sseClientTransport) postMessage(ctx context.Context, message interface{}) error {
 	// Auto-start the transport if not already started.
 	if !t.started.Load() {
 		return errors.New("transport not started")
@@ -465,8 +551,8 @@ func (t *sseClientTransport) sendNotification(ctx context.Context, notification
 		return errors.New("endpoint URL not received")
 	}
 
-	// Marshal the notification.
-	notificationBytes, err := json.Marshal(notification)
+	// Marshal the message.
+	notificationBytes, err := json.Marshal(message)
 	if err != nil {
 		return fmt.Errorf("%w: %v", ErrNotificationSerialization, err)
 	}
@@ -503,10 +589,9 @@ func (t *sseClientTransport) sendNotification(ctx context.Context, notification
 	return nil
 }
 
-// sendResponse sends a response (client doesn't send responses in standard case).
+// sendResponse sends a response to a server-initiated request.
 func (t *sseClientTransport) sendResponse(ctx context.Context, resp *JSONRPCResponse) error {
-	// In typical client usage, this shouldn't be called
-	return errors.New("SSE client transport doesn't support sending responses")
+	return t.postMessage(ctx, resp)
 }
 
 // close closes the transport.
@@ -114,6 +114,7 @@ type SSEServer struct {
 	keepAlive         bool                                                       // Whether to keep the connection alive.
 	keepAliveInterval time.Duration                                              // Keep-alive interval.
 	logger            Logger                                                     // Logger for this server.
+	clientResponses   *clientResponseRouter                                      // Router for client responses to server requests.
 }
 
 // SSEOption defines a function type for configuring the SSE server.
@@ -157,8 +158,15 @@ func NewSSEServer(name, version string, opts ...SSEOption) *SSEServer {
 		keepAlive:         true,
 		keepAliveInterval: 30 * time.Second,
 		logger:            GetDefaultLogger(),
+		clientResponses:   newClientResponseRouter(),
 	}
 
+	// Notify initialized sessions when the registered tools, resources or prompts change.
+	listChangedNotifier := newListChangedNotifier(defaultListChangedDebounce, s.broadcastListChanged)
+	toolManager.withListChangedNotifier(listChangedNotifier)
+	resourceManager.withListChangedNotifier(listChangedNotifier)
+	promptManager.withListChangedNotifier(listChangedNotifier)
+
 	// Apply all options.
 	for _, opt := range opts {
 		opt(s)
@@ -223,6 +231,50 @@ func WithSSEContextFunc(fn func(ctx context.Context, r *http.Request) context.Co
 	}
 }
 
+// WithSSERootsListChangedHandler sets a callback invoked when a client sends notifications/roots/list_changed.
+func WithSSERootsListChangedHandler(handler RootsListChangedHandler) SSEOption {
+	return func(s *SSEServer) {
+		s.mcpHandler.rootsListChangedHandler = handler
+	}
+}
+
+// WithSSEPageSize sets the maximum number of items returned per page by list requests.
+func WithSSEPageSize(pageSize int) SSEOption {
+	return func(s *SSEServer) {
+		s.toolManager.withPageSize(pageSize)
+		s.resourceManager.withPageSize(pageSize)
+		s.promptManager.withPageSize(pageSize)
+	}
+}
+
+// WithSSEToolInputValidation sets whether tool call arguments are validated against the input schema of tools.
+func WithSSEToolInputValidation(enabled bool) SSEOption {
+	return func(s *SSEServer) {
+		s.toolManager.withInputValidation(enabled)
+	}
+}
+
+// WithSSEMiddleware adds middlewares wrapping the handling of every request and notification.
+func WithSSEMiddleware(middlewares ...ServerMiddleware) SSEOption {
+	return func(s *SSEServer) {
+		withMiddlewares(middlewares...)(s.mcpHandler)
+	}
+}
+
+// WithSSEToolListFilter sets a tool list filter that will be applied to tools/list requests.
+func WithSSEToolListFilter(filter ToolListFilter) SSEOption {
+	return func(s *SSEServer) {
+		s.toolManager.withToolListFilter(filter)
+	}
+}
+
+// WithSSEMethodNameModifier sets a method name modifier for external customization, e.g. monitoring.
+func WithSSEMethodNameModifier(modifier MethodNameModifier) SSEOption {
+	return func(s *SSEServer) {
+		s.toolManager.withMethodNameModifier(modifier)
+	}
+}
+
 // WithSSEServerLogger sets the logger for the SSE server.
 func WithSSEServerLogger(logger Logger) SSEOption {
 	return func(s *SSEServer) {
@@ -336,15 +388,25 @@ func (s *SSEServer) handleSSE(w http.ResponseWriter, r *http.Request) {
 	// Send initial connection message.
 	sendSSEComment(w, flusher, &session.writeMu, "connection established")
 
+	// Writers of the response, which must stop before the handler returns.
+	var writers sync.WaitGroup
+	startWriter := func(write func()) {
+		writers.Add(1)
+		go func() {
+			defer writers.Done()
+			write()
+		}()
+	}
+
 	// Start notification handler.
-	go handleNotifications(s.logger, w, flusher, session)
+	startWriter(func() { handleNotifications(s.logger, w, flusher, session) })
 
 	// Start event queue handler.
-	go handleEventQueue(s.logger, w, flusher, session)
+	startWriter(func() { handleEventQueue(s.logger, w, flusher, session) })
 
 	// Start keep-alive handler.
 	if s.keepAlive {
-		go handleKeepAlive(s.logger, w, flusher, session, s.keepAliveInterval)
+		startWriter(func() { handleKeepAlive(s.logger, w, flusher, session, s.keepAliveInterval) })
 	}
 
 	// Wait for connection to close.
@@ -359,7 +421,9 @@ func (s *SSEServer) handleSSE(w http.ResponseWriter, r *http.Request) {
 
 	// Clean up resources.
 	close(session.done)
+	writers.Wait()
 	s.sessions.Delete(sessionID)
+	s.mcpHandler.onSessionTerminated(sessionID)
 	s.logger.Debugf("Cleaned up session %s", sessionID)
 }
 
@@ -468,9 +532,22 @@ func (s *SSEServer) handleMessage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Responses to server-initiated requests carry an ID but no method.
+	if request.Method == "" && request.ID != nil {
+		s.handleClientResponse(w, r, request.ID, session)
+		return
+	}
+
 	// Create context with session.
 	ctx := s.createSessionContext(r.Context(), session)
 
+	// Notifications carry no ID and expect no response.
+	if request.ID == nil {
+		s.handleClientNotification(ctx, r, session)
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
 	// Immediately return HTTP 202 Accepted status code, indicating request has been received.
 	w.WriteHeader(http.StatusAccepted)
 
@@ -534,23 +611,119 @@ func (s *SSEServer) parseJSONRPCRequest(r *http.Request) (*JSONRPCRequest, error
 
 	// Parse request body.
 	var request JSONRPCRequest
-	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+	if err := json.Unmarshal(requestBody, &request); err != nil {
 		return nil, fmt.Errorf("error decoding request: %v", err)
 	}
 
 	return &request, nil
 }
 
+// handleClientResponse delivers a client response to the pending server-initiated request.
+func (s *SSEServer) handleClientResponse(w http.ResponseWriter, r *http.Request, id interface{}, session *sseSession) {
+	rawMessage, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if !s.clientResponses.deliver(session.sessionID, id, rawMessage) {
+		s.logger.Infof("Received response for unknown request ID: %v", id)
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// handleClientNotification processes a notification sent by the client.
+func (s *SSEServer) handleClientNotification(ctx context.Context, r *http.Request, session *sseSession) {
+	var notification JSONRPCNotification
+	if err := json.NewDecoder(r.Body).Decode(&notification); err != nil {
+		s.logger.Errorf("Error parsing notification: %v", err)
+		return
+	}
+
+	if err := s.mcpHandler.handleNotification(ctx, &notification, session); err != nil {
+		s.logger.Errorf("Error handling notification %s: %v", notification.Method, err)
+		return
+	}
+
+	// Server notifications can be pushed once the client has confirmed initialization.
+	if notification.Method == MethodNotificationsInitialized {
+		session.Initialize()
+	}
+}
+
 // createSessionContext creates a context with session information.
 func (s *SSEServer) createSessionContext(ctx context.Context, session *sseSession) context.Context {
 	// Use sessionKey structure as context key.
 	type sessionKey struct{}
 	ctx = context.WithValue(ctx, sessionKey{}, session)
+	ctx = setSessionToContext(ctx, session)
+
+	// Allow handlers to send notifications and requests back to the client.
+	ctx = withNotificationSender(ctx, &sseSessionNotificationSender{session: session})
+	ctx = withClientRequestSender(ctx, &sseClientRequestSender{server: s, session: session})
 
 	// Set server instance to context.
 	return setServerToContext(ctx, s)
 }
 
+// sseClientRequestSender sends server-initiated requests over the session's SSE stream.
+type sseClientRequestSender struct {
+	server  *SSEServer
+	session *sseSession
+}
+
+// sendClientRequest implements clientRequestSender.
+func (c *sseClientRequestSender) sendClientRequest(ctx context.Context, method string, params interface{}) (json.RawMessage, error) {
+	return c.server.clientResponses.sendAndWait(ctx, c.session.sessionID, method, params, func(req *JSONRPCRequest) error {
+		data, err := json.Marshal(req)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrNotificationSerialization, err)
+		}
+		select {
+		case c.session.eventQueue <- formatSSEEvent("message", data):
+			return nil
+		case <-c.session.done:
+			return fmt.Errorf("session closed: %s", c.session.sessionID)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+// sseSessionNotificationSender sends notifications over the session's SSE stream.
+// Notifications share the event queue with responses, so they are delivered before the response of the request sending them.
+type sseSessionNotificationSender struct {
+	session *sseSession
+}
+
+// SendLogMessage sends a log message notification.
+func (n *sseSessionNotificationSender) SendLogMessage(level string, message string) error {
+	return n.SendCustomNotification(NotificationMethodMessage, newLogMessageParams(level, message))
+}
+
+// SendProgress does nothing, progress notifications require the progress token of the request.
+func (n *sseSessionNotificationSender) SendProgress(progress, total float64, message string) error {
+	return nil
+}
+
+// SendCustomNotification sends a custom notification.
+func (n *sseSessionNotificationSender) SendCustomNotification(method string, params map[string]interface{}) error {
+	return n.SendNotification(NewNotification(method, params))
+}
+
+// SendNotification sends a notification.
+func (n *sseSessionNotificationSender) SendNotification(notification *Notification) error {
+	data, err := json.Marshal(newJSONRPCNotification(*notification))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrNotificationSerialization, err)
+	}
+	select {
+	case n.session.eventQueue <- formatSSEEvent("message", data):
+		return nil
+	case <-n.session.done:
+		return fmt.Errorf("session closed: %s", n.session.sessionID)
+	}
+}
+
 // processRequestAsync processes the request asynchronously.
 func (s *SSEServer) processRequestAsync(ctx context.Context, request *JSONRPCRequest, session *sseSession) {
 	// Create a context that will not be canceled due to HTTP connection closure.
@@ -662,6 +835,39 @@ func (s *SSEServer) SendNotification(sessionID string, method string, params map
 	return s.sendNotificationToSession(sessionID, notification)
 }
 
+// NotifyResourceUpdated sends notifications/resources/updated to the sessions subscribed to the resource.
+// It returns an error if sending to any session failed.
+func (s *SSEServer) NotifyResourceUpdated(uri string) error {
+	notification := newResourceUpdatedNotification(uri)
+	sessions := s.resourceManager.getSubscribers(uri)
+
+	failedCount := 0
+	var lastError error
+	for _, sessionID := range sessions {
+		if err := s.sendNotificationToSession(sessionID, notification); err != nil {
+			failedCount++
+			lastError = err
+		}
+	}
+	if failedCount > 0 {
+		return fmt.Errorf("failed to notify %d of %d subscribed sessions: %w", failedCount, len(sessions), lastError)
+	}
+	return nil
+}
+
+// broadcastListChanged sends a list_changed notification to all initialized sessions.
+func (s *SSEServer) broadcastListChanged(method string) {
+	notification := NewJSONRPCNotificationFromMap(method, nil)
+	s.sessions.Range(func(key, value interface{}) bool {
+		if session, ok := value.(*sseSession); ok && session.Initialized() {
+			if err := s.sendNotificationToSession(session.GetID(), notification); err != nil {
+				s.logger.Debugf("Failed to send %s to session %s: %v", method, session.GetID(), err)
+			}
+		}
+		return true
+	})
+}
+
 // SendNotificationToSession sends a notification to a specific session.
 func (s *SSEServer) sendNotificationToSession(sessionID string, notification *JSONRPCNotification) error {
 	// Get session
@@ -760,6 +966,35 @@ func (s *SSEServer) UnregisterTools(names ...string) error {
 	return nil
 }
 
+// UnregisterPrompts removes multiple prompts by names and returns an error if no prompts were unregistered.
+func (s *SSEServer) UnregisterPrompts(names ...string) error {
+	return unregisterNamed("prompt", names, s.promptManager.unregisterPrompts)
+}
+
+// UnregisterResources removes multiple resources by URIs and returns an error if no resources were unregistered.
+// Subscriptions to the removed resources are dropped.
+func (s *SSEServer) UnregisterResources(uris ...string) error {
+	return unregisterNamed("resource", uris, s.resourceManager.unregisterResources)
+}
+
+// UnregisterResourceTemplates removes multiple resource templates by names and returns an error
+// if no templates were unregistered.
+func (s *SSEServer) UnregisterResourceTemplates(names ...string) error {
+	return unregisterNamed("resource template", names, s.resourceManager.unregisterTemplates)
+}
+
+// ReplaceTools atomically replaces all registered tools with the given ones, listed in the given order.
+// Nothing is changed if a tool is invalid or two tools share a name.
+func (s *SSEServer) ReplaceTools(tools []ServerTool) error {
+	return s.toolManager.replaceTools(tools)
+}
+
+// ReplacePrompts atomically replaces all registered prompts with the given ones, listed in the given order.
+// Nothing is changed if a prompt is invalid or two prompts share a name.
+func (s *SSEServer) ReplacePrompts(prompts []ServerPrompt) error {
+	return s.promptManager.replacePrompts(prompts)
+}
+
 // RegisterResource registers a resource with its handler.
 func (s *SSEServer) RegisterResource(resource *Resource, handler resourceHandler) {
 	if resource == nil || handler == nil {
@@ -8,7 +8,9 @@ package mcp
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sync"
 	"sync/atomic"
 	"time"
 )
@@ -39,7 +41,11 @@ type StdioClient struct {
 	initialized     atomic.Bool
 	requestID       atomic.Int64
 	capabilities    map[string]interface{}
+	capabilitiesMu  sync.Mutex
+	rootsAdvertised bool
 	state           atomic.Value // stores State
+	roots           clientRoots
+	interceptors    clientInterceptors
 	logger          Logger
 }
 
@@ -56,7 +62,7 @@ func NewStdioClient(config StdioTransportConfig, clientInfo Implementation, opti
 	// Create client.
 	client := &StdioClient{
 		clientInfo:      clientInfo,
-		protocolVersion: ProtocolVersion_2025_03_26,
+		protocolVersion: ProtocolVersion_2025_06_18,
 		capabilities:    make(map[string]interface{}),
 		logger:          GetDefaultLogger(),
 	}
@@ -80,6 +86,12 @@ func NewStdioClient(config StdioTransportConfig, clientInfo Implementation, opti
 
 	// Create transport.
 	client.transport = newStdioClientTransport(config.ServerParams, transportOptions...)
+	client.transport.setInterceptors(client.interceptors)
+
+	// Answer roots/list requests if roots were configured.
+	if client.roots.enabled {
+		client.enableRoots()
+	}
 
 	return client, nil
 }
@@ -107,6 +119,22 @@ func WithStdioCapabilities(capabilities map[string]interface{}) StdioClientOptio
 	}
 }
 
+// WithStdioRoots sets the roots exposed to the server and advertises the roots capability.
+func WithStdioRoots(roots []Root) StdioClientOption {
+	return func(c *StdioClient) {
+		c.roots.enabled = true
+		c.roots.set(roots)
+	}
+}
+
+// WithStdioClientInterceptor adds interceptors wrapping every request sent by the client and every
+// notification received by it.
+func WithStdioClientInterceptor(interceptors ...ClientInterceptor) StdioClientOption {
+	return func(c *StdioClient) {
+		c.interceptors = append(c.interceptors, interceptors...)
+	}
+}
+
 // Initialize initializes the client connection
 func (c *StdioClient) Initialize(ctx context.Context, req *InitializeRequest) (*InitializeResult, error) {
 	if c.initialized.Load() {
@@ -115,19 +143,25 @@ func (c *StdioClient) Initialize(ctx context.Context, req *InitializeRequest) (*
 
 	// Create initialization request
 	requestID := c.requestID.Add(1)
+	capabilities := c.getCapabilities()
 	jsonReq := newJSONRPCRequest(requestID, MethodInitialize, map[string]interface{}{
 		"protocolVersion": c.protocolVersion,
 		"clientInfo":      c.clientInfo,
-		"capabilities":    c.capabilities,
+		"capabilities":    capabilities,
 	})
+	_, rootsAdvertised := capabilities["roots"]
 
 	// Override with provided params if any.
 	if req != nil && !isZeroStruct(req.Params) {
 		jsonReq.Params = req.Params
+		rootsAdvertised = req.Params.Capabilities.Roots != nil
 	}
+	c.capabilitiesMu.Lock()
+	c.rootsAdvertised = rootsAdvertised
+	c.capabilitiesMu.Unlock()
 
 	// Send request
-	rawResp, err := c.transport.sendRequest(ctx, jsonReq)
+	rawResp, err := c.sendRequest(ctx, jsonReq)
 	if err != nil {
 		c.setState(StateDisconnected)
 		return nil, fmt.Errorf("initialization failed: %w", err)
@@ -168,6 +202,11 @@ func (c *StdioClient) Initialize(ctx context.Context, req *InitializeRequest) (*
 	return initResult, nil
 }
 
+// sendRequest sends a request to the server through the interceptors.
+func (c *StdioClient) sendRequest(ctx context.Context, req *JSONRPCRequest) (*json.RawMessage, error) {
+	return c.interceptors.sendRequest(ctx, req, c.transport.sendRequest)
+}
+
 // sendInitialized sends the initialized notification
 func (c *StdioClient) sendInitialized(ctx context.Context) error {
 	notification := NewInitializedNotification()
@@ -214,7 +253,7 @@ func (c *StdioClient) ListTools(ctx context.Context, req *ListToolsRequest) (*Li
 		Params: req.Params,
 	}
 
-	rawResp, err := c.transport.sendRequest(ctx, jsonReq)
+	rawResp, err := c.sendRequest(ctx, jsonReq)
 	if err != nil {
 		return nil, fmt.Errorf("list tools request failed: %w", err)
 	}
@@ -228,23 +267,50 @@ func (c *StdioClient) ListTools(ctx context.Context, req *ListToolsRequest) (*Li
 			errResp.Error.Message, errResp.Error.Code)
 	}
 
-	return parseListToolsResultFromJSON(rawResp)
+	return parseListToolsResultFromJSON(rawResp, c.logger)
 }
 
 // CallTool calls a specific tool.
 func (c *StdioClient) CallTool(ctx context.Context, req *CallToolRequest) (*CallToolResult, error) {
+	return c.CallToolWithOptions(ctx, req)
+}
+
+// CallToolWithOptions calls a specific tool with per-call options.
+func (c *StdioClient) CallToolWithOptions(
+	ctx context.Context,
+	req *CallToolRequest,
+	options ...CallToolOption,
+) (*CallToolResult, error) {
 	if !c.initialized.Load() {
 		return nil, fmt.Errorf("client not initialized")
 	}
 
 	requestID := c.requestID.Add(1)
-	jsonReq := newJSONRPCRequest(requestID, MethodToolsCall, map[string]interface{}{
-		"name":      req.Params.Name,
-		"arguments": req.Params.Arguments,
-	})
+	params := req.Params
 
-	rawResp, err := c.transport.sendRequest(ctx, jsonReq)
+	// Route progress notifications of this call to its handler, using the request ID as default token
+	callOptions := newCallToolOptions(options)
+	if callOptions.progressHandler != nil {
+		var token ProgressToken
+		params, token = withProgressToken(params, requestID)
+		unregister := c.transport.registerProgressHandler(token, callOptions.progressHandler)
+		defer unregister()
+	}
+
+	callParams := map[string]interface{}{
+		"name":      params.Name,
+		"arguments": params.Arguments,
+	}
+	if params.Meta != nil {
+		callParams["_meta"] = params.Meta
+	}
+	jsonReq := newJSONRPCRequest(requestID, MethodToolsCall, callParams)
+
+	rawResp, err := c.sendRequest(ctx, jsonReq)
 	if err != nil {
+		if ctx.Err() != nil {
+			go c.notifyCancelled(requestID, ctx.Err().Error())
+		}
 		return nil, fmt.Errorf("call tool request failed: %w", err)
 	}
 
@@ -260,6 +326,17 @@ func (c *StdioClient) CallTool(ctx context.Context, req *CallToolRequest) (*Call
 	return parseCallToolResult(rawResp)
 }
 
+// notifyCancelled tells the server to stop processing a request the caller has given up on.
+func (c *StdioClient) notifyCancelled(requestID interface{}, reason string) {
+	ctx, cancel := context.WithTimeout(context.Background(), cancelledNotificationTimeout)
+	defer cancel()
+
+	notification := NewCancelledNotification(requestID, reason)
+	if err := c.transport.sendNotification(ctx, notification); err != nil && c.logger != nil {
+		c.logger.Debugf("Failed to send cancelled notification for request %v: %v", requestID, err)
+	}
+}
+
 // ListPrompts lists available prompts.
 func (c *StdioClient) ListPrompts(ctx context.Context, req *ListPromptsRequest) (*ListPromptsResult, error) {
 	if !c.initialized.Load() {
@@ -276,7 +353,7 @@ func (c *StdioClient) ListPrompts(ctx context.Context, req *ListPromptsRequest)
 		Params: req.Params,
 	}
 
-	rawResp, err := c.transport.sendRequest(ctx, jsonReq)
+	rawResp, err := c.sendRequest(ctx, jsonReq)
 	if err != nil {
 		return nil, fmt.Errorf("list prompts request failed: %w", err)
 	}
@@ -305,7 +382,7 @@ func (c *StdioClient) GetPrompt(ctx context.Context, req *GetPromptRequest) (*Ge
 		"arguments": req.Params.Arguments,
 	})
 
-	rawResp, err := c.transport.sendRequest(ctx, jsonReq)
+	rawResp, err := c.sendRequest(ctx, jsonReq)
 	if err != nil {
 		return nil, fmt.Errorf("get prompt request failed: %w", err)
 	}
@@ -338,7 +415,7 @@ func (c *StdioClient) ListResources(ctx context.Context, req *ListResourcesReque
 		Params: req.Params,
 	}
 
-	rawResp, err := c.transport.sendRequest(ctx, jsonReq)
+	rawResp, err := c.sendRequest(ctx, jsonReq)
 	if err != nil {
 		return nil, fmt.Errorf("list resources request failed: %w", err)
 	}
@@ -355,6 +432,106 @@ func (c *StdioClient) ListResources(ctx context.Context, req *ListResourcesReque
 	return parseListResourcesResultFromJSON(rawResp)
 }
 
+// ListResourceTemplates lists available resource templates.
+func (c *StdioClient) ListResourceTemplates(
+	ctx context.Context,
+	req *ListResourceTemplatesRequest,
+) (*ListResourceTemplatesResult, error) {
+	if !c.initialized.Load() {
+		return nil, fmt.Errorf("client not initialized")
+	}
+
+	requestID := c.requestID.Add(1)
+	jsonReq := &JSONRPCRequest{
+		JSONRPC: JSONRPCVersion,
+		ID:      requestID,
+		Request: Request{
+			Method: MethodResourcesTemplatesList,
+		},
+		Params: req.Params,
+	}
+
+	rawResp, err := c.sendRequest(ctx, jsonReq)
+	if err != nil {
+		return nil, fmt.Errorf("list resource templates request failed: %w", err)
+	}
+
+	if isErrorResponse(rawResp) {
+		errResp, err := parseRawMessageToError(rawResp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse error response: %w", err)
+		}
+		return nil, fmt.Errorf("list resource templates error: %s (code: %d)",
+			errResp.Error.Message, errResp.Error.Code)
+	}
+
+	return parseListResourceTemplatesResultFromJSON(rawResp)
+}
+
+// ListAllTools lists available tools, following pagination cursors until the last page.
+func (c *StdioClient) ListAllTools(ctx context.Context) ([]Tool, error) {
+	var tools []Tool
+	err := listAllPages(func(cursor Cursor) (Cursor, error) {
+		req := &ListToolsRequest{}
+		req.Params.Cursor = cursor
+		result, err := c.ListTools(ctx, req)
+		if err != nil {
+			return "", err
+		}
+		tools = append(tools, result.Tools...)
+		return result.NextCursor, nil
+	})
+	return tools, err
+}
+
+// ListAllPrompts lists available prompts, following pagination cursors until the last page.
+func (c *StdioClient) ListAllPrompts(ctx context.Context) ([]Prompt, error) {
+	var prompts []Prompt
+	err := listAllPages(func(cursor Cursor) (Cursor, error) {
+		req := &ListPromptsRequest{}
+		req.Params.Cursor = cursor
+		result, err := c.ListPrompts(ctx, req)
+		if err != nil {
+			return "", err
+		}
+		prompts = append(prompts, result.Prompts...)
+		return result.NextCursor, nil
+	})
+	return prompts, err
+}
+
+// ListAllResources lists available resources, following pagination cursors until the last page.
+func (c *StdioClient) ListAllResources(ctx context.Context) ([]Resource, error) {
+	var resources []Resource
+	err := listAllPages(func(cursor Cursor) (Cursor, error) {
+		req := &ListResourcesRequest{}
+		req.Params.Cursor = cursor
+		result, err := c.ListResources(ctx, req)
+		if err != nil {
+			return "", err
+		}
+		resources = append(resources, result.Resources...)
+		return result.NextCursor, nil
+	})
+	return resources, err
+}
+
+// ListAllResourceTemplates lists available resource templates, following pagination cursors until the last page.
+func (c *StdioClient) ListAllResourceTemplates(ctx context.Context) ([]ResourceTemplate, error) {
+	var templates []ResourceTemplate
+	err := listAllPages(func(cursor Cursor) (Cursor, error) {
+		req := &ListResourceTemplatesRequest{}
+		req.Params.Cursor = cursor
+		result, err := c.ListResourceTemplates(ctx, req)
+		if err != nil {
+			return "", err
+		}
+		templates = append(templates, result.ResourceTemplates...)
+		return result.NextCursor, nil
+	})
+	return templates, err
+}
+
 // ReadResource reads a specific resource.
 func (c *StdioClient) ReadResource(ctx context.Context, req *ReadResourceRequest) (*ReadResourceResult, error) {
 	if !c.initialized.Load() {
@@ -367,7 +544,7 @@ func (c *StdioClient) ReadResource(ctx context.Context, req *ReadResourceRequest
 		"arguments": req.Params.Arguments,
 	})
 
-	rawResp, err := c.transport.sendRequest(ctx, jsonReq)
+	rawResp, err := c.sendRequest(ctx, jsonReq)
 	if err != nil {
 		return nil, fmt.Errorf("read resource request failed: %w", err)
 	}
@@ -384,6 +561,121 @@ func (c *StdioClient) ReadResource(ctx context.Context, req *ReadResourceRequest
 	return parseReadResourceResultFromJSON(rawResp)
 }
 
+// Complete asks the server for completion values of a prompt argument or resource template variable.
+func (c *StdioClient) Complete(ctx context.Context, req *CompleteRequest) (*CompleteResult, error) {
+	if !c.initialized.Load() {
+		return nil, fmt.Errorf("client not initialized")
+	}
+
+	requestID := c.requestID.Add(1)
+	jsonReq := &JSONRPCRequest{
+		JSONRPC: JSONRPCVersion,
+		ID:      requestID,
+		Request: Request{
+			Method: MethodCompletionComplete,
+		},
+		Params: req.Params,
+	}
+
+	rawResp, err := c.sendRequest(ctx, jsonReq)
+	if err != nil {
+		return nil, fmt.Errorf("complete request failed: %w", err)
+	}
+
+	if isErrorResponse(rawResp) {
+		errResp, err := parseRawMessageToError(rawResp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse error response: %w", err)
+		}
+		return nil, fmt.Errorf("complete error: %s (code: %d)",
+			errResp.Error.Message, errResp.Error.Code)
+	}
+
+	return parseCompleteResultFromJSON(rawResp)
+}
+
+// SetLoggingLevel sets the minimum level of log messages the server sends to this client.
+func (c *StdioClient) SetLoggingLevel(ctx context.Context, level LoggingLevel) error {
+	if !c.initialized.Load() {
+		return fmt.Errorf("client not initialized")
+	}
+
+	requestID := c.requestID.Add(1)
+	jsonReq := newJSONRPCRequest(requestID, MethodLoggingSetLevel, map[string]interface{}{
+		"level": level,
+	})
+
+	rawResp, err := c.sendRequest(ctx, jsonReq)
+	if err != nil {
+		return fmt.Errorf("set logging level request failed: %w", err)
+	}
+
+	if isErrorResponse(rawResp) {
+		errResp, err := parseRawMessageToError(rawResp)
+		if err != nil {
+			return fmt.Errorf("failed to parse error response: %w", err)
+		}
+		return fmt.Errorf("set logging level error: %s (code: %d)",
+			errResp.Error.Message, errResp.Error.Code)
+	}
+	return nil
+}
+
+// SubscribeResource subscribes to updates of a resource, the server then sends
+// notifications/resources/updated when the resource changes.
+func (c *StdioClient) SubscribeResource(ctx context.Context, uri string) error {
+	if !c.initialized.Load() {
+		return fmt.Errorf("client not initialized")
+	}
+
+	requestID := c.requestID.Add(1)
+	jsonReq := newJSONRPCRequest(requestID, MethodResourcesSubscribe, map[string]interface{}{
+		"uri": uri,
+	})
+
+	rawResp, err := c.sendRequest(ctx, jsonReq)
+	if err != nil {
+		return fmt.Errorf("subscribe resource request failed: %w", err)
+	}
+
+	if isErrorResponse(rawResp) {
+		errResp, err := parseRawMessageToError(rawResp)
+		if err != nil {
+			return fmt.Errorf("failed to parse error response: %w", err)
+		}
+		return fmt.Errorf("subscribe resource error: %s (code: %d)",
+			errResp.Error.Message, errResp.Error.Code)
+	}
+	return nil
+}
+
+// UnsubscribeResource cancels a subscription to updates of a resource.
+func (c *StdioClient) UnsubscribeResource(ctx context.Context, uri string) error {
+	if !c.initialized.Load() {
+		return fmt.Errorf("client not initialized")
+	}
+
+	requestID := c.requestID.Add(1)
+	jsonReq := newJSONRPCRequest(requestID, MethodResourcesUnsubscribe, map[string]interface{}{
+		"uri": uri,
+	})
+
+	rawResp, err := c.sendRequest(ctx, jsonReq)
+	if err != nil {
+		return fmt.Errorf("unsubscribe resource request failed: %w", err)
+	}
+
+	if isErrorResponse(rawResp) {
+		errResp, err := parseRawMessageToError(rawResp)
+		if err != nil {
+			return fmt.Errorf("failed to parse error response: %w", err)
+		}
+		return fmt.Errorf("unsubscribe resource error: %s (code: %d)",
+			errResp.Error.Message, errResp.Error.Code)
+	}
+	return nil
+}
+
 // RegisterNotificationHandler registers a notification handler.
 func (c *StdioClient) RegisterNotificationHandler(method string, handler NotificationHandler) {
 	c.transport.registerNotificationHandler(method, handler)
@@ -394,6 +686,92 @@ func (c *StdioClient) UnregisterNotificationHandler(method string) {
 	c.transport.unregisterNotificationHandler(method)
 }
 
+// RegisterRequestHandler registers a handler for requests initiated by the server.
+// The handler's result is sent back to the server as the JSON-RPC response.
+func (c *StdioClient) RegisterRequestHandler(method string, handler RequestHandler) {
+	c.transport.registerRequestHandler(method, handler)
+}
+
+// UnregisterRequestHandler unregisters a handler for requests initiated by the server.
+func (c *StdioClient) UnregisterRequestHandler(method string) {
+	c.transport.unregisterRequestHandler(method)
+}
+
+// RegisterSamplingHandler registers a handler for sampling/createMessage requests.
+// The sampling capability is advertised if the handler is registered before Initialize.
+func (c *StdioClient) RegisterSamplingHandler(handler SamplingHandler) {
+	c.setCapability("sampling", map[string]interface{}{})
+	c.RegisterRequestHandler(MethodSamplingCreateMessage, newSamplingRequestHandler(handler))
+}
+
+// RegisterElicitationHandler registers a handler for elicitation/create requests.
+// The elicitation capability is advertised if the handler is registered before Initialize.
+func (c *StdioClient) RegisterElicitationHandler(handler ElicitationHandler) {
+	c.setCapability("elicitation", map[string]interface{}{})
+	c.RegisterRequestHandler(MethodElicitationCreate, newElicitationRequestHandler(handler))
+}
+
+// RegisterRootsListHandler registers a handler for roots/list requests.
+// The roots capability is advertised if the handler is registered before Initialize.
+func (c *StdioClient) RegisterRootsListHandler(handler RootsListHandler) {
+	c.capabilitiesMu.Lock()
+	if _, ok := c.capabilities["roots"]; !ok {
+		c.capabilities["roots"] = map[string]interface{}{}
+	}
+	c.capabilitiesMu.Unlock()
+	c.RegisterRequestHandler(MethodRootsList, newRootsListRequestHandler(handler))
+}
+
+// setCapability sets a capability advertised at initialization.
+func (c *StdioClient) setCapability(name string, capability interface{}) {
+	c.capabilitiesMu.Lock()
+	defer c.capabilitiesMu.Unlock()
+	c.capabilities[name] = capability
+}
+
+// getCapabilities returns a copy of the capabilities advertised at initialization.
+func (c *StdioClient) getCapabilities() map[string]interface{} {
+	c.capabilitiesMu.Lock()
+	defer c.capabilitiesMu.Unlock()
+	capabilities := make(map[string]interface{}, len(c.capabilities))
+	for name, capability := range c.capabilities {
+		capabilities[name] = capability
+	}
+	return capabilities
+}
+
+// RegisterPingHandler registers a handler for ping requests.
+// Ping requests are answered automatically if no handler is registered.
+func (c *StdioClient) RegisterPingHandler(handler PingHandler) {
+	c.RegisterRequestHandler(MethodPing, newPingRequestHandler(handler))
+}
+
+// SetRoots replaces the roots exposed to the server.
+// If the client is initialized and advertised the roots capability, the server is notified with
+// notifications/roots/list_changed.
+func (c *StdioClient) SetRoots(ctx context.Context, roots []Root) error {
+	c.roots.set(roots)
+	c.capabilitiesMu.Lock()
+	enable := !c.roots.enabled
+	c.roots.enabled = true
+	notify := c.rootsAdvertised
+	c.capabilitiesMu.Unlock()
+	if enable {
+		c.enableRoots()
+	}
+
+	if !c.initialized.Load() || !notify {
+		return nil
+	}
+	return c.transport.sendNotification(ctx, NewRootsListChangedNotification())
+}
+
+// enableRoots advertises the roots capability and answers roots/list requests.
+func (c *StdioClient) enableRoots() {
+	c.setCapability("roots", map[string]interface{}{"listChanged": true})
+	c.RegisterRequestHandler(MethodRootsList, newRootsListRequestHandler(c.roots.handleListRoots))
+}
+
 // GetProcessID returns the process ID.
 func (c *StdioClient) GetProcessID() int {
 	return c.transport.getProcessID()
@@ -40,6 +40,9 @@ type streamableHTTPClientTransport struct {
 	// Session ID
 	sessionID string
 
+	// Protocol version negotiated during initialization, sent in the MCP-Protocol-Version header
+	protocolVersion string
+
 	// Notification handlers
 	notificationHandlers map[string]NotificationHandler
 
@@ -60,6 +63,19 @@ type streamableHTTPClientTransport struct {
 	// Notification handlers mutex
 	handlersMutex sync.RWMutex
 
+	// Mutex of the session ID, protocol version, last event ID, stateless mode and GET SSE flag,
+	// which are shared by requests and the GET SSE connection
+	stateMutex sync.RWMutex
+
+	// Handlers for server-initiated requests
+	requestHandlers *clientRequestHandlers
+
+	// Handlers for progress notifications of in-flight requests
+	progressHandlers *clientProgressHandlers
+
+	// Interceptors of received notifications
+	interceptors clientInterceptors
+
 	// Whether in stateless mode
 	// In stateless mode, the client will not send a session ID and will not attempt to establish a GET SSE connection.
 	// This field is set by auto-detection when no session ID is provided in the initialize response.
@@ -100,6 +116,8 @@ func newStreamableHTTPClientTransport(config *transportConfig, options ...transp
 		httpClient:            config.httpClient,
 		httpHeaders:           config.httpHeaders,
 		notificationHandlers:  make(map[string]NotificationHandler),
+		requestHandlers:       newClientRequestHandlers(),
+		progressHandlers:      newClientProgressHandlers(),
 		enableGetSSE:          config.enableGetSSE,
 		logger:                config.logger,
 		serviceName:           config.serviceName,
@@ -220,15 +238,18 @@ func (t *streamableHTTPClientTransport) send(
 	// Set request headers - accept both SSE and JSON responses
 	httpReq.Header.Set(httputil.ContentTypeHeader, httputil.ContentTypeJSON)
 	httpReq.Header.Set(httputil.AcceptHeader, httputil.ContentTypeJSON+", "+httputil.ContentTypeSSE)
-	if t.sessionID != "" && !t.isStateless {
-		httpReq.Header.Set(httputil.SessionIDHeader, t.sessionID)
+	if sessionID := t.getSessionID(); sessionID != "" && !t.isStatelessMode() {
+		httpReq.Header.Set(httputil.SessionIDHeader, sessionID)
+	}
+	if protocolVersion := t.getProtocolVersion(); protocolVersion != "" {
+		httpReq.Header.Set(httputil.ProtocolVersionHeader, protocolVersion)
 	}
 
 	// If lastEventID is provided, attach it to the request
 	if options != nil && options.lastEventID != "" {
 		httpReq.Header.Set(httputil.LastEventIDHeader, options.lastEventID)
-	} else if t.lastEventID != "" {
-		httpReq.Header.Set(httputil.LastEventIDHeader, t.lastEventID)
+	} else if lastEventID := t.getLastEventID(); lastEventID != "" {
+		httpReq.Header.Set(httputil.LastEventIDHeader, lastEventID)
 	}
 
 	// Add custom headers
@@ -247,11 +268,10 @@ func (t *streamableHTTPClientTransport) send(
 	// Handle session ID
 	if sessionID := httpResp.Header.Get(httputil.SessionIDHeader); sessionID != "" {
 		t.setSessionID(sessionID)
-		t.isStateless = false
-	} else if req.Method == MethodInitialize && !t.isStateless {
+		t.setStateless(false)
+	} else if req.Method == MethodInitialize && !t.isStatelessMode() {
 		// If this is an initialize request and no session ID was received, auto-detect as stateless mode
-		t.isStateless = true
-		t.enableGetSSE = false // Disable GET SSE in stateless mode
+		t.setStateless(true)
 	}
 
 	// Check content type
@@ -306,6 +326,7 @@ func (t *streamableHTTPClientTransport) send(
 
 // processEventData processes SSE event data and returns the processed message
 func (t *streamableHTTPClientTransport) processEventData(
+	ctx context.Context,
 	data string,
 	reqID interface{},
 	handlers map[string]NotificationHandler,
@@ -313,6 +334,12 @@ func (t *streamableHTTPClientTransport) processEventData(
 	// Create a raw message from the data
 	rawMessage := json.RawMessage(data)
 
+	// Requests initiated by the server are answered asynchronously
+	if msgType, err := parseJSONRPCMessageType(rawMessage); err == nil && msgType == JSONRPCMessageTypeRequest {
+		go t.handleServerRequest(ctx, rawMessage)
+		return nil, nil
+	}
+
 	// First, check if it's a response to our request by looking at the ID
 	var jsonResp map[string]interface{}
 	if err := json.Unmarshal(rawMessage, &jsonResp); err == nil {
@@ -359,18 +386,30 @@ func (t *streamableHTTPClientTransport) handleNotificationMessage(
 ) (*json.RawMessage, error) {
 	var notification JSONRPCNotification
 	if err := json.Unmarshal(rawMessage, &notification); err == nil && notification.Method != "" {
-		// Process notification
-		if handler, ok := handlers[notification.Method]; ok {
-			if err := handler(&notification); err != nil {
-				t.logger.Infof("Notification handler error: %v", err)
+		err := t.interceptors.handleNotification(&notification, func(notification *JSONRPCNotification) error {
+			// Deliver progress to the handler of the request it belongs to
+			t.progressHandlers.handle(notification)
+
+			// Process notification
+			handler, ok := handlers[notification.Method]
+			if !ok {
+				t.logger.Infof("Received unhandled notification: %s", notification.Method)
+				return nil
 			}
-		} else {
-			t.logger.Infof("Received unhandled notification: %s", notification.Method)
+			return handler(notification)
+		})
+		if err != nil {
+			t.logger.Infof("Notification handler error: %v", err)
 		}
 	}
 	return nil, nil
 }
 
+// setInterceptors sets the interceptors of received notifications
+func (t *streamableHTTPClientTransport) setInterceptors(interceptors clientInterceptors) {
+	t.interceptors = interceptors
+}
+
 // Handle SSE response
 func (t *streamableHTTPClientTransport) handleSSEResponse(
 	ctx context.Context,
@@ -423,14 +462,14 @@ func (t *streamableHTTPClientTransport) handleSSEResponse(
 
 			// Process event ID
 			if strings.HasPrefix(line, "id:") {
-				t.lastEventID = strings.TrimSpace(strings.TrimPrefix(line, "id:"))
+				t.setLastEventID(strings.TrimSpace(strings.TrimPrefix(line, "id:")))
 				continue
 			}
 
 			// Process event data
 			if strings.HasPrefix(line, "data:") {
 				data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
-				result, err := t.processEventData(data, reqID, handlers)
+				result, err := t.processEventData(ctx, data, reqID, handlers)
 				if err != nil {
 					return nil, err
 				}
@@ -462,35 +501,54 @@ func (t *streamableHTTPClientTransport) unregisterNotificationHandler(method str
 	delete(t.notificationHandlers, method)
 }
 
+// registerRequestHandler registers a handler for server-initiated requests
+func (t *streamableHTTPClientTransport) registerRequestHandler(method string, handler RequestHandler) {
+	t.requestHandlers.register(method, handler)
+}
+
+// unregisterRequestHandler unregisters a handler for server-initiated requests
+func (t *streamableHTTPClientTransport) unregisterRequestHandler(method string) {
+	t.requestHandlers.unregister(method)
+}
+
+// registerProgressHandler registers a handler for the progress notifications of a request
+func (t *streamableHTTPClientTransport) registerProgressHandler(token ProgressToken, handler ProgressHandler) func() {
+	return t.progressHandlers.register(token, handler)
+}
+
+// handleServerRequest dispatches a server-initiated request and posts the response back to the server
+func (t *streamableHTTPClientTransport) handleServerRequest(ctx context.Context, rawMessage json.RawMessage) {
+	var req JSONRPCRequest
+	if err := json.Unmarshal(rawMessage, &req); err != nil {
+		t.logger.Infof("Failed to parse server request: %v", err)
+		return
+	}
+
+	reply := t.requestHandlers.handle(ctx, &req)
+
+	// The response must be delivered even if the stream that carried the request is done
+	if err := t.postMessage(context.WithoutCancel(ctx), reply); err != nil {
+		t.logger.Infof("Failed to send response for server request %s: %v", req.Method, err)
+	}
+}
+
 // sendNotification sends a notification (no response expected)
 func (t *streamableHTTPClientTransport) sendNotification(ctx context.Context, notification *JSONRPCNotification) error {
-	// Serialize notification to JSON
-	notifBytes, err := json.Marshal(notification)
+	return t.postMessage(ctx, notification)
+}
+
+// postMessage posts a JSON-RPC notification or response to the server (no response expected)
+func (t *streamableHTTPClientTransport) postMessage(ctx context.Context, message interface{}) error {
+	// Serialize message to JSON
+	notifBytes, err := json.Marshal(message)
 	if err != nil {
-		return fmt.Errorf("failed to serialize notification: %w", err)
+		return fmt.Errorf("failed to serialize message: %w", err)
 	}
 
 	// Create HTTP request
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, t.serverURL.String(), bytes.NewReader(notifBytes))
+	httpReq, err := t.newPostRequest(ctx, notifBytes)
 	if err != nil {
-		return fmt.Errorf("%w: %v", ErrHTTPRequestCreation, err)
-	}
-	if len(t.path) != 0 {
-		httpReq.URL.Path = t.path
-	}
-
-	// Set request headers - must accept both JSON and SSE responses per MCP specification.
-	httpReq.Header.Set(httputil.ContentTypeHeader, httputil.ContentTypeJSON)
-	httpReq.Header.Set(httputil.AcceptHeader, httputil.ContentTypeJSON+", "+httputil.ContentTypeSSE)
-	if t.sessionID != "" {
-		httpReq.Header.Set(httputil.SessionIDHeader, t.sessionID)
-	}
-
-	// Add custom headers
-	for key, values := range t.httpHeaders {
-		for _, value := range values {
-			httpReq.Header.Add(key, value)
-		}
+		return err
 	}
 
 	// Send request
@@ -502,7 +560,7 @@ func (t *streamableHTTPClientTransport) sendNotification(ctx context.Context, no
 
 	// Handle session ID
 	if sessionID := httpResp.Header.Get(httputil.SessionIDHeader); sessionID != "" {
-		t.sessionID = sessionID
+		t.setSessionID(sessionID)
 	}
 
 	// Check status code
@@ -520,9 +578,141 @@ func (t *streamableHTTPClientTransport) sendNotification(ctx context.Context, no
 	return nil
 }
 
-// SendResponse sends a response (clients don't need to implement this method)
+// newPostRequest creates a POST request carrying a JSON-RPC message and the headers of the session
+func (t *streamableHTTPClientTransport) newPostRequest(ctx context.Context, body []byte) (*http.Request, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, t.serverURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrHTTPRequestCreation, err)
+	}
+	if len(t.path) != 0 {
+		httpReq.URL.Path = t.path
+	}
+
+	// Set request headers - must accept both JSON and SSE responses per MCP specification.
+	httpReq.Header.Set(httputil.ContentTypeHeader, httputil.ContentTypeJSON)
+	httpReq.Header.Set(httputil.AcceptHeader, httputil.ContentTypeJSON+", "+httputil.ContentTypeSSE)
+	if sessionID := t.getSessionID(); sessionID != "" {
+		httpReq.Header.Set(httputil.SessionIDHeader, sessionID)
+	}
+	if protocolVersion := t.getProtocolVersion(); protocolVersion != "" {
+		httpReq.Header.Set(httputil.ProtocolVersionHeader, protocolVersion)
+	}
+
+	// Add custom headers
+	for key, values := range t.httpHeaders {
+		for _, value := range values {
+			httpReq.Header.Add(key, value)
+		}
+	}
+	return httpReq, nil
+}
+
+// sendBatch sends messages as one JSON-RPC batch and returns the responses to its requests,
+// which the server returns either as a JSON array or as separate events of an SSE stream
+func (t *streamableHTTPClientTransport) sendBatch(ctx context.Context, messages []interface{}) ([]json.RawMessage, error) {
+	if protocolVersion := t.getProtocolVersion(); protocolVersion != "" && !protocolVersionSupportsBatching(protocolVersion) {
+		return nil, fmt.Errorf("%w in protocol version %s", ErrBatchNotSupported, protocolVersion)
+	}
+
+	// Count the requests, whose responses end the batch
+	expected := 0
+	for _, message := range messages {
+		if _, ok := message.(*JSONRPCRequest); ok {
+			expected++
+		}
+	}
+
+	batchBytes, err := json.Marshal(messages)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRequestSerialization, err)
+	}
+	httpReq, err := t.newPostRequest(ctx, batchBytes)
+	if err != nil {
+		return nil, err
+	}
+	httpResp, err := t.httpReqHandler.Handle(ctx, t.httpClient, httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrHTTPRequestFailed, err)
+	}
+	defer httpResp.Body.Close()
+
+	switch {
+	case httpResp.StatusCode == http.StatusAccepted:
+		return nil, nil
+	case httpResp.StatusCode != http.StatusOK:
+		body, _ := io.ReadAll(httpResp.Body)
+		return nil, fmt.Errorf("%w: status code %d: %s", ErrHTTPRequestFailed, httpResp.StatusCode, strings.TrimSpace(string(body)))
+	case strings.Contains(httpResp.Header.Get(httputil.ContentTypeHeader), httputil.ContentTypeSSE):
+		return t.readBatchSSEResponses(ctx, httpResp.Body, expected)
+	}
+
+	respBytes, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if !isBatchMessage(respBytes) {
+		// A single error response rejects the whole batch
+		return []json.RawMessage{respBytes}, nil
+	}
+	var responses []json.RawMessage
+	if err := json.Unmarshal(respBytes, &responses); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrResponseParsing, err)
+	}
+	return responses, nil
+}
+
+// readBatchSSEResponses reads the responses to a batch from an SSE stream until all expected responses arrived,
+// handling the notifications and server-initiated requests sent in between
+func (t *streamableHTTPClientTransport) readBatchSSEResponses(
+	ctx context.Context,
+	body io.Reader,
+	expected int,
+) ([]json.RawMessage, error) {
+	t.handlersMutex.RLock()
+	handlers := make(map[string]NotificationHandler, len(t.notificationHandlers))
+	for method, handler := range t.notificationHandlers {
+		handlers[method] = handler
+	}
+	t.handlersMutex.RUnlock()
+
+	reader := bufio.NewReader(body)
+	var responses []json.RawMessage
+	for len(responses) < expected {
+		if err := ctx.Err(); err != nil {
+			return responses, err
+		}
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if err == io.EOF {
+				return responses, fmt.Errorf("connection closed after %d of %d batch responses", len(responses), expected)
+			}
+			return responses, fmt.Errorf("failed to read SSE event: %w", err)
+		}
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		rawMessage := json.RawMessage(strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+		msgType, err := parseJSONRPCMessageType(rawMessage)
+		if err != nil {
+			t.logger.Infof("Failed to parse SSE event of batch: %v", err)
+			continue
+		}
+		switch msgType {
+		case JSONRPCMessageTypeRequest:
+			go t.handleServerRequest(ctx, rawMessage)
+		case JSONRPCMessageTypeNotification:
+			t.handleNotificationMessage(rawMessage, handlers)
+		case JSONRPCMessageTypeResponse, JSONRPCMessageTypeError:
+			responses = append(responses, rawMessage)
+		}
+	}
+	return responses, nil
+}
+
+// SendResponse sends a response to a server-initiated request
 func (t *streamableHTTPClientTransport) sendResponse(ctx context.Context, resp *JSONRPCResponse) error {
-	return fmt.Errorf("client transport does not support sending responses")
+	return t.postMessage(ctx, resp)
 }
 
 // Close closes the transport connection
@@ -543,16 +733,51 @@ func (t *streamableHTTPClientTransport) close() error {
 	return nil
 }
 
-// GetSessionID gets the session ID
+// getSessionID gets the session ID
 func (t *streamableHTTPClientTransport) getSessionID() string {
+	t.stateMutex.RLock()
+	defer t.stateMutex.RUnlock()
 	return t.sessionID
 }
 
-// SetSessionID sets the session ID
+// setSessionID sets the session ID
 func (t *streamableHTTPClientTransport) setSessionID(sessionID string) {
+	t.stateMutex.Lock()
+	defer t.stateMutex.Unlock()
 	t.sessionID = sessionID
 }
 
+// getLastEventID gets the ID of the last received event
+func (t *streamableHTTPClientTransport) getLastEventID() string {
+	t.stateMutex.RLock()
+	defer t.stateMutex.RUnlock()
+	return t.lastEventID
+}
+
+// setLastEventID sets the ID of the last received event
+func (t *streamableHTTPClientTransport) setLastEventID(eventID string) {
+	t.stateMutex.Lock()
+	defer t.stateMutex.Unlock()
+	t.lastEventID = eventID
+}
+
+// setStateless sets whether the client is in stateless mode, which disables GET SSE
+func (t *streamableHTTPClientTransport) setStateless(stateless bool) {
+	t.stateMutex.Lock()
+	defer t.stateMutex.Unlock()
+	t.isStateless = stateless
+	if stateless {
+		t.enableGetSSE = false
+	}
+}
+
+// isGetSSEEnabled reports whether the client establishes a GET SSE connection
+func (t *streamableHTTPClientTransport) isGetSSEEnabled() bool {
+	t.stateMutex.RLock()
+	defer t.stateMutex.RUnlock()
+	return t.enableGetSSE
+}
+
 // Establish GET SSE connection
 func (t *streamableHTTPClientTransport) establishGetSSE() {
 	// Get lock to ensure only one active connection
@@ -591,7 +816,8 @@ func (t *streamableHTTPClientTransport) establishGetSSE() {
 // Connect to GET SSE endpoint
 func (t *streamableHTTPClientTransport) connectGetSSE(ctx context.Context) error {
 	// Check if there's a session ID
-	if t.sessionID == "" {
+	sessionID := t.getSessionID()
+	if sessionID == "" {
 		return fmt.Errorf("cannot establish GET SSE connection: session ID is empty")
 	}
 
@@ -606,9 +832,12 @@ func (t *streamableHTTPClientTransport) connectGetSSE(ctx context.Context) error
 
 	// Set necessary headers
 	req.Header.Set(httputil.AcceptHeader, httputil.ContentTypeSSE)
-	req.Header.Set(httputil.SessionIDHeader, t.sessionID)
-	if t.lastEventID != "" {
-		req.Header.Set(httputil.LastEventIDHeader, t.lastEventID)
+	req.Header.Set(httputil.SessionIDHeader, sessionID)
+	if protocolVersion := t.getProtocolVersion(); protocolVersion != "" {
+		req.Header.Set(httputil.ProtocolVersionHeader, protocolVersion)
+	}
+	if lastEventID := t.getLastEventID(); lastEventID != "" {
+		req.Header.Set(httputil.LastEventIDHeader, lastEventID)
 	}
 
 	// Add custom headers
@@ -618,7 +847,7 @@ func (t *streamableHTTPClientTransport) connectGetSSE(ctx context.Context) error
 		}
 	}
 
-	t.logger.Debugf("Attempting to establish GET SSE connection, session ID: %s", t.sessionID)
+	t.logger.Debugf("Attempting to establish GET SSE connection, session ID: %s", t.getSessionID())
 
 	// Send request
 	resp, err := t.httpReqHandler.Handle(ctx, t.httpClient, req)
@@ -638,7 +867,7 @@ func (t *streamableHTTPClientTransport) connectGetSSE(ctx context.Context) error
 	}
 
 	// Handle response
-	t.logger.Debugf("GET SSE connection established, session ID: %s", t.sessionID)
+	t.logger.Debugf("GET SSE connection established, session ID: %s", t.getSessionID())
 
 	// Handle SSE event stream
 	return t.handleGetSSEEvents(ctx, resp.Body)
@@ -661,7 +890,7 @@ func (t *streamableHTTPClientTransport) handleGetSSEEvents(ctx context.Context,
 			if line == "" {
 				// Check if there's a complete event
 				if eventData != "" {
-					t.processSSEEvent(eventID, eventData)
+					t.processSSEEvent(ctx, eventID, eventData)
 					eventID, eventData = "", ""
 				}
 				continue
@@ -671,7 +900,7 @@ func (t *streamableHTTPClientTransport) handleGetSSEEvents(ctx context.Context,
 			if strings.HasPrefix(line, "id:") {
 				eventID = strings.TrimPrefix(line, "id:")
 				eventID = strings.TrimSpace(eventID)
-				t.lastEventID = eventID
+				t.setLastEventID(eventID)
 			} else if strings.HasPrefix(line, "data:") {
 				data := strings.TrimPrefix(line, "data:")
 				data = strings.TrimSpace(data)
@@ -688,7 +917,7 @@ func (t *streamableHTTPClientTransport) handleGetSSEEvents(ctx context.Context,
 }
 
 // Process SSE event
-func (t *streamableHTTPClientTransport) processSSEEvent(eventID, eventData string) {
+func (t *streamableHTTPClientTransport) processSSEEvent(ctx context.Context, eventID, eventData string) {
 	// Ignore empty events
 	if eventData == "" {
 		return
@@ -705,6 +934,9 @@ func (t *streamableHTTPClientTransport) processSSEEvent(eventID, eventData strin
 	if msgType == JSONRPCMessageTypeNotification {
 		notification := message.(*JSONRPCNotification)
 
+		// Deliver progress to the handler of the request it belongs to
+		t.progressHandlers.handle(notification)
+
 		// Call the appropriate handler
 		t.handlersMutex.RLock()
 		handler, ok := t.notificationHandlers[notification.Method]
@@ -722,8 +954,11 @@ func (t *streamableHTTPClientTransport) processSSEEvent(eventID, eventData strin
 			t.logger.Debugf("Received notification with no registered handler: %s",
 				formatJSONRPCMessage(notification))
 		}
+	} else if msgType == JSONRPCMessageTypeRequest {
+		// Requests initiated by the server are answered asynchronously
+		go t.handleServerRequest(ctx, json.RawMessage(eventData))
 	} else {
-		// In GET SSE connection, we expect to receive only notifications
+		// In GET SSE connection, we expect to receive only notifications and requests
 		t.logger.Debugf("GET SSE connection received non-notification message, type: %s, ignored", msgType)
 	}
 }
@@ -740,11 +975,14 @@ func (t *streamableHTTPClientTransport) terminateSession(ctx context.Context) er
 	}
 
 	// Set session ID header
-	if t.sessionID != "" {
-		httpReq.Header.Set(httputil.SessionIDHeader, t.sessionID)
+	if sessionID := t.getSessionID(); sessionID != "" {
+		httpReq.Header.Set(httputil.SessionIDHeader, sessionID)
 	} else {
 		return fmt.Errorf("no active session")
 	}
+	if protocolVersion := t.getProtocolVersion(); protocolVersion != "" {
+		httpReq.Header.Set(httputil.ProtocolVersionHeader, protocolVersion)
+	}
 
 	// Add custom headers
 	for key, values := range t.httpHeaders {
@@ -768,7 +1006,7 @@ func (t *streamableHTTPClientTransport) terminateSession(ctx context.Context) er
 	}
 
 	// Session successfully terminated, clear session ID
-	t.sessionID = ""
+	t.setSessionID("")
 
 	return nil
 }
@@ -782,6 +1020,8 @@ func (t *streamableHTTPClientTransport) terminateSession(ctx context.Context) er
 // If it returns true, the client is currently running in stateless mode and will not include
 // a session ID in requests or attempt to establish GET SSE connections.
 func (t *streamableHTTPClientTransport) isStatelessMode() bool {
+	t.stateMutex.RLock()
+	defer t.stateMutex.RUnlock()
 	return t.isStateless
 }
 
@@ -794,14 +1034,28 @@ func (t *streamableHTTPClientTransport) sendRequestWithStream(
 	return t.send(ctx, req, options)
 }
 
+// getProtocolVersion gets the protocol version negotiated during initialization.
+func (t *streamableHTTPClientTransport) getProtocolVersion() string {
+	t.stateMutex.RLock()
+	defer t.stateMutex.RUnlock()
+	return t.protocolVersion
+}
+
+// setProtocolVersion sets the protocol version negotiated during initialization.
+func (t *streamableHTTPClientTransport) setProtocolVersion(version string) {
+	t.stateMutex.Lock()
+	defer t.stateMutex.Unlock()
+	t.protocolVersion = version
+}
+
 // establishGetSSEConnection attempts to establish a GET SSE connection if enabled
 func (t *streamableHTTPClientTransport) establishGetSSEConnection() {
-	if !t.enableGetSSE {
+	if !t.isGetSSEEnabled() {
 		t.logger.Debug("GET SSE is not enabled, will not establish GET SSE connection")
 		return
 	}
 
-	if t.sessionID == "" {
+	if t.getSessionID() == "" {
 		t.logger.Debug("Session ID is empty, cannot establish GET SSE connection")
 		return
 	}
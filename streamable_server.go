@@ -20,6 +20,9 @@ import (
 const (
 	// defaultSessionExpirySeconds is the default session expiration time (seconds)
 	defaultSessionExpirySeconds = 3600 // 1 hour
+
+	// defaultBatchConcurrency is the default maximum number of batch messages processed concurrently
+	defaultBatchConcurrency = 10
 )
 
 // requestHandler interface defines a component that handles requests
@@ -68,6 +71,12 @@ type httpServerHandler struct {
 
 	// Server path.
 	serverPath string
+
+	// Router correlating client responses with server-initiated requests
+	clientResponses *clientResponseRouter
+
+	// Maximum number of messages of a JSON-RPC batch processed concurrently
+	batchConcurrency int
 }
 
 // getSSEConnection represents a GET SSE connection
@@ -81,6 +90,9 @@ type getSSEConnection struct {
 	// Prevent concurrent write conflicts
 	writeLock sync.Mutex
 
+	// Whether the GET request has ended, guarded by writeLock
+	closed bool
+
 	// Event ID generator, reuses existing sseResponder
 	sseResponder *sseResponder
 }
@@ -97,6 +109,8 @@ func newHTTPServerHandler(handler requestHandler, serverPath string, options ...
 		enableGetSSE:           true, // Default: GET SSE enabled
 		getSSEConnections:      make(map[string]*getSSEConnection),
 		serverPath:             serverPath,
+		clientResponses:        newClientResponseRouter(),
+		batchConcurrency:       defaultBatchConcurrency,
 	}
 
 	// Apply options
@@ -180,6 +194,15 @@ func withTransportStatelessMode() func(*httpServerHandler) {
 	}
 }
 
+// withTransportBatchConcurrency sets the maximum number of batch messages processed concurrently
+func withTransportBatchConcurrency(concurrency int) func(*httpServerHandler) {
+	return func(h *httpServerHandler) {
+		if concurrency > 0 {
+			h.batchConcurrency = concurrency
+		}
+	}
+}
+
 // withTransportHTTPContextFuncs sets the HTTP context functions
 func withTransportHTTPContextFuncs(funcs []HTTPContextFunc) func(*httpServerHandler) {
 	return func(h *httpServerHandler) {
@@ -238,6 +261,11 @@ func (h *httpServerHandler) handlePost(ctx context.Context, w http.ResponseWrite
 	cancel := context.CancelFunc(func() {}) // Placeholder to keep defer cancel() syntax consistent
 	defer cancel()
 
+	if isBatchMessage(rawMessage) {
+		h.handlePostBatch(enrichedCtx, w, r, rawMessage)
+		return
+	}
+
 	var isInitialize bool
 	var base baseMessage
 
@@ -252,29 +280,15 @@ func (h *httpServerHandler) handlePost(ctx context.Context, w http.ResponseWrite
 		isInitialize = true
 	}
 
-	// Get session
-	var session Session
-	if h.isStateless {
-		// Stateless mode: create a temporary session for each request.
-		session = newSession()
-	} else if h.enableSession {
-		// Stateful mode
-		sessionIDHeader := r.Header.Get(httputil.SessionIDHeader)
-		if sessionIDHeader != "" {
-			var ok bool
-			session, ok = h.sessionManager.getSession(sessionIDHeader)
-			if !ok {
-				http.Error(w, "Session not found or expired", http.StatusNotFound) // 404 if session ID provided but not found
-				return
-			}
-		} else if isInitialize {
-			// If it's an initialize request and no session ID header, create a new session
-			session = h.sessionManager.createSession()
-			h.logger.Infof("Created new session ID: %s for initialize request", session.GetID())
-		} else {
-			// Not an initialize request and no session ID header was provided.
-			// According to MCP spec, server SHOULD respond with 400 Bad Request.
-			http.Error(w, "Missing Mcp-Session-Id header for non-initialize request", http.StatusBadRequest)
+	session, ok := h.getPostSession(w, r, isInitialize)
+	if !ok {
+		return
+	}
+
+	// Validate the protocol version header, initialize requests negotiate the version instead
+	if !isInitialize {
+		if err := h.checkProtocolVersion(r, session); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
 	}
@@ -288,11 +302,233 @@ func (h *httpServerHandler) handlePost(ctx context.Context, w http.ResponseWrite
 		h.handlePostNotification(enrichedCtx, w, r, rawMessage, base, session)
 		return
 	}
+	if base.ID != nil && base.Method == "" {
+		h.handlePostResponse(w, rawMessage, base, session)
+		return
+	}
 
 	// Unable to parse request
 	http.Error(w, "Invalid JSON-RPC message", http.StatusBadRequest)
 }
 
+// getPostSession gets the session of a POST request, creating one for initialize requests.
+// It writes an error response and returns false if the session cannot be resolved.
+func (h *httpServerHandler) getPostSession(w http.ResponseWriter, r *http.Request, isInitialize bool) (Session, bool) {
+	if h.isStateless {
+		// Stateless mode: create a temporary session for each request.
+		return newSession(), true
+	}
+	if !h.enableSession {
+		return nil, true
+	}
+
+	// Stateful mode
+	sessionIDHeader := r.Header.Get(httputil.SessionIDHeader)
+	if sessionIDHeader != "" {
+		session, ok := h.getSession(r, sessionIDHeader)
+		if !ok {
+			http.Error(w, "Session not found or expired", http.StatusNotFound) // 404 if session ID provided but not found
+			return nil, false
+		}
+		return session, true
+	}
+	if isInitialize {
+		// If it's an initialize request and no session ID header, create a new session
+		session := h.sessionManager.createSession()
+		bindSession(r.Context(), session)
+		h.logger.Infof("Created new session ID: %s for initialize request", session.GetID())
+		return session, true
+	}
+	// Not an initialize request and no session ID header was provided.
+	// According to MCP spec, server SHOULD respond with 400 Bad Request.
+	http.Error(w, "Missing Mcp-Session-Id header for non-initialize request", http.StatusBadRequest)
+	return nil, false
+}
+
+// getSession gets the session of a request by ID, hiding sessions bound to the principal of another access token
+func (h *httpServerHandler) getSession(r *http.Request, sessionID string) (Session, bool) {
+	session, ok := h.sessionManager.getSession(sessionID)
+	if !ok || !sessionAllowed(r.Context(), session) {
+		return nil, false
+	}
+	return session, true
+}
+
+// handlePostBatch handles JSON-RPC batch messages.
+// Requests and notifications of the batch are processed concurrently, responses of the client to
+// server-initiated requests are delivered immediately. The responses to the requests are returned
+// as a JSON array, or as separate events of one SSE stream when the client accepts SSE.
+func (h *httpServerHandler) handlePostBatch(ctx context.Context, w http.ResponseWriter, r *http.Request, rawMessage json.RawMessage) {
+	var messages []json.RawMessage
+	if err := json.Unmarshal(rawMessage, &messages); err != nil || len(messages) == 0 {
+		http.Error(w, ErrInvalidRequestBody.Error(), http.StatusBadRequest)
+		return
+	}
+	bases := make([]baseMessage, len(messages))
+	var firstRequest json.RawMessage
+	for i, message := range messages {
+		// Malformed messages are answered with an Invalid Request error
+		if err := json.Unmarshal(message, &bases[i]); err != nil {
+			continue
+		}
+		if bases[i].Method == MethodInitialize {
+			http.Error(w, "initialize request must not be part of a JSON-RPC batch", http.StatusBadRequest)
+			return
+		}
+		if firstRequest == nil && bases[i].ID != nil && bases[i].Method != "" {
+			firstRequest = message
+		}
+	}
+
+	session, ok := h.getPostSession(w, r, false)
+	if !ok {
+		return
+	}
+	if err := h.checkProtocolVersion(r, session); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	version := r.Header.Get(httputil.ProtocolVersionHeader)
+	if negotiated := getSessionProtocolVersion(session); negotiated != "" {
+		version = negotiated
+	}
+	if version != "" && !protocolVersionSupportsBatching(version) {
+		http.Error(w, fmt.Sprintf("%v in protocol version %s", ErrBatchNotSupported, version), http.StatusBadRequest)
+		return
+	}
+
+	// Stream the responses when the client accepts SSE for the requests of the batch
+	var sse *sseNotificationSender
+	if firstRequest != nil {
+		if _, ok := h.responderFactory.createResponder(r, firstRequest).(*sseResponder); ok {
+			flusher, ok := w.(http.Flusher)
+			if !ok {
+				http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+				return
+			}
+			sseutil.SetStandardHeaders(w)
+			if !h.isStateless && session != nil {
+				w.Header().Set(httputil.SessionIDHeader, session.GetID())
+			}
+			var sessionID string
+			if session != nil {
+				sessionID = session.GetID()
+			}
+			sse = newSSENotificationSender(w, flusher, sessionID)
+		}
+	}
+
+	responses := make([]JSONRPCMessage, len(messages))
+	semaphore := make(chan struct{}, h.batchConcurrency)
+	var wg sync.WaitGroup
+	for i, message := range messages {
+		base := bases[i]
+		if base.Method == "" {
+			if base.ID != nil {
+				h.deliverClientResponse(message, base, session)
+				continue
+			}
+			responses[i] = newJSONRPCErrorResponse(nil, ErrCodeInvalidRequest, "Invalid JSON-RPC message", nil)
+			if sse != nil {
+				if err := sse.sendMessage(responses[i]); err != nil {
+					h.logger.Infof("Failed to send SSE batch response: %v", err)
+				}
+			}
+			continue
+		}
+
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int, message json.RawMessage, isRequest bool) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			if !isRequest {
+				var notification JSONRPCNotification
+				if err := json.Unmarshal(message, &notification); err != nil {
+					h.logger.Infof("Invalid notification in batch: %v", err)
+					return
+				}
+				if err := h.processNotification(ctx, &notification, session); err != nil {
+					h.logger.Infof("Notification processing failed: %v", err)
+				}
+				return
+			}
+			var req JSONRPCRequest
+			if err := json.Unmarshal(message, &req); err != nil {
+				responses[i] = newJSONRPCErrorResponse(bases[i].ID, ErrCodeInvalidRequest, "Invalid JSON-RPC request format: "+err.Error(), nil)
+			} else {
+				responses[i] = h.processBatchRequest(ctx, &req, session, sse)
+			}
+			if sse != nil {
+				if err := sse.sendMessage(responses[i]); err != nil {
+					h.logger.Infof("Failed to send SSE batch response: %v", err)
+				}
+			}
+		}(i, message, base.ID != nil)
+	}
+	wg.Wait()
+
+	if sse != nil {
+		return
+	}
+	results := make([]JSONRPCMessage, 0, len(responses))
+	for _, resp := range responses {
+		if resp != nil {
+			results = append(results, resp)
+		}
+	}
+	if len(results) == 0 {
+		// The batch only contained notifications and responses
+		h.sendNotificationResponse(w, session)
+		return
+	}
+	responder := newJSONResponder(withJSONStatelessMode(h.isStateless))
+	if err := responder.respond(ctx, w, r, results, session); err != nil {
+		h.logger.Infof("Failed to send batch response: %v", err)
+	}
+}
+
+// processBatchRequest processes a request of a batch and returns its response.
+// Unlike single requests, error responses are returned as they are rather than as a result.
+func (h *httpServerHandler) processBatchRequest(ctx context.Context, req *JSONRPCRequest, session Session, sse *sseNotificationSender) JSONRPCMessage {
+	resp, err := h.processRequest(ctx, req, session, sse)
+	if err != nil {
+		h.logger.Infof("Request processing failed: %v", err)
+		return newJSONRPCErrorResponse(req.ID, ErrCodeInternal, "Internal server error", nil)
+	}
+	if errResp, ok := resp.(*JSONRPCError); ok {
+		return errResp
+	}
+	return newJSONRPCResponse(req.ID, resp)
+}
+
+// checkProtocolVersion validates the MCP-Protocol-Version header of a request.
+// The header is only required once a session has negotiated 2025-06-18 or newer,
+// so clients using older protocol versions keep working without it.
+func (h *httpServerHandler) checkProtocolVersion(r *http.Request, session Session) error {
+	header := r.Header.Get(httputil.ProtocolVersionHeader)
+	if header != "" && !IsProtocolVersionSupported(header) {
+		return fmt.Errorf("%w: %s", ErrUnsupportedProtocolVersion, header)
+	}
+
+	negotiated := getSessionProtocolVersion(session)
+	if negotiated == "" {
+		return nil
+	}
+	if header == "" {
+		if protocolVersionRequiresHeader(negotiated) {
+			return fmt.Errorf("%w: missing %s header", ErrUnsupportedProtocolVersion, httputil.ProtocolVersionHeader)
+		}
+		return nil
+	}
+	if header != negotiated {
+		return fmt.Errorf("%w: %s does not match negotiated version %s", ErrUnsupportedProtocolVersion, header, negotiated)
+	}
+	return nil
+}
+
 // handlePostRequest handles JSON-RPC requests
 func (h *httpServerHandler) handlePostRequest(ctx context.Context, w http.ResponseWriter, r *http.Request, rawMessage json.RawMessage, base baseMessage, session Session) {
 	respCtx, cancel := context.WithCancel(ctx)
@@ -323,11 +559,7 @@ func (h *httpServerHandler) handlePostRequest(ctx context.Context, w http.Respon
 			sessionID = session.GetID()
 		}
 		notificationSender := newSSENotificationSender(w, flusher, sessionID)
-		reqCtx := withNotificationSender(ctx, notificationSender)
-		if session != nil {
-			reqCtx = setSessionToContext(reqCtx, session)
-		}
-		resp, err := h.requestHandler.handleRequest(reqCtx, &req, session)
+		resp, err := h.processRequest(ctx, &req, session, notificationSender)
 		if err != nil {
 			h.logger.Infof("Request processing failed: %v", err)
 			errorResp := newJSONRPCErrorResponse(req.ID, ErrCodeInternal, "Internal server error", nil)
@@ -349,12 +581,7 @@ func (h *httpServerHandler) handlePostRequest(ctx context.Context, w http.Respon
 		return
 	}
 	// Use normal JSON response mode
-	noopSender := &noopNotificationSender{}
-	reqCtx := withNotificationSender(ctx, noopSender)
-	if session != nil {
-		reqCtx = setSessionToContext(reqCtx, session)
-	}
-	resp, err := h.requestHandler.handleRequest(reqCtx, &req, session)
+	resp, err := h.processRequest(ctx, &req, session, nil)
 	if err != nil {
 		h.logger.Infof("Request processing failed: %v", err)
 		errorResp := newJSONRPCErrorResponse(req.ID, ErrCodeInternal, "Internal server error", nil)
@@ -369,6 +596,39 @@ func (h *httpServerHandler) handlePostRequest(ctx context.Context, w http.Respon
 	responder.respond(respCtx, w, r, jsonrpcResponse, session)
 }
 
+// processRequest passes a request to the request handler. Notifications and server-initiated requests
+// are written to the SSE stream of the POST request when sse is set.
+func (h *httpServerHandler) processRequest(ctx context.Context, req *JSONRPCRequest, session Session, sse *sseNotificationSender) (JSONRPCMessage, error) {
+	var reqCtx context.Context
+	if sse != nil {
+		reqCtx = withNotificationSender(ctx, sse)
+	} else {
+		reqCtx = withNotificationSender(ctx, &noopNotificationSender{})
+	}
+	reqCtx = withClientRequestSender(reqCtx, h.newClientRequestSender(session, sse))
+	if session != nil {
+		reqCtx = setSessionToContext(reqCtx, session)
+	}
+	return h.requestHandler.handleRequest(reqCtx, req, session)
+}
+
+// handlePostResponse handles JSON-RPC responses sent by the client for server-initiated requests
+func (h *httpServerHandler) handlePostResponse(w http.ResponseWriter, rawMessage json.RawMessage, base baseMessage, session Session) {
+	h.deliverClientResponse(rawMessage, base, session)
+	h.sendNotificationResponse(w, session)
+}
+
+// deliverClientResponse delivers a response of the client to the server-initiated request waiting for it
+func (h *httpServerHandler) deliverClientResponse(rawMessage json.RawMessage, base baseMessage, session Session) {
+	var sessionID string
+	if !h.isStateless && session != nil {
+		sessionID = session.GetID()
+	}
+	if !h.clientResponses.deliver(sessionID, base.ID, rawMessage) {
+		h.logger.Infof("Received response for unknown request ID: %v", base.ID)
+	}
+}
+
 // handlePostNotification handles JSON-RPC notifications
 func (h *httpServerHandler) handlePostNotification(ctx context.Context, w http.ResponseWriter, r *http.Request, rawMessage json.RawMessage, base baseMessage, session Session) {
 	var notification JSONRPCNotification
@@ -376,6 +636,16 @@ func (h *httpServerHandler) handlePostNotification(ctx context.Context, w http.R
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
+	if err := h.processNotification(ctx, &notification, session); err != nil {
+		h.logger.Infof("Notification processing failed: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	h.sendNotificationResponse(w, session)
+}
+
+// processNotification passes a notification to the request handler
+func (h *httpServerHandler) processNotification(ctx context.Context, notification *JSONRPCNotification, session Session) error {
 	if notification.Method == MethodNotificationsInitialized {
 		if h.enableSession && session == nil {
 			h.logger.Info("Warning: Received initialized notification but no active session")
@@ -383,20 +653,14 @@ func (h *httpServerHandler) handlePostNotification(ctx context.Context, w http.R
 		// In stateless mode, skip initialization state check and return success directly.
 		if h.isStateless {
 			h.logger.Debug("Stateless mode: Skipping initialization state check for notifications/initialized")
-			h.sendNotificationResponse(w, session)
-			return
+			return nil
 		}
 	}
-	notificationCtx := ctx
+	notificationCtx := withClientRequestSender(ctx, h.newClientRequestSender(session, nil))
 	if session != nil {
-		notificationCtx = setSessionToContext(ctx, session)
+		notificationCtx = setSessionToContext(notificationCtx, session)
 	}
-	if err := h.requestHandler.handleNotification(notificationCtx, &notification, session); err != nil {
-		h.logger.Infof("Notification processing failed: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
-		return
-	}
-	h.sendNotificationResponse(w, session)
+	return h.requestHandler.handleNotification(notificationCtx, notification, session)
 }
 
 // handleDelete handles DELETE requests
@@ -410,11 +674,26 @@ func (h *httpServerHandler) handleDelete(ctx context.Context, w http.ResponseWri
 
 	// Get session
 	if h.enableSession {
+		session, ok := h.getSession(r, sessionID)
+		if !ok {
+			http.Error(w, "Session not found", http.StatusNotFound)
+			return
+		}
+		if err := h.checkProtocolVersion(r, session); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
 		// Terminate session
 		if h.sessionManager.terminateSession(sessionID) {
 			// Clean up GET SSE connections
 			h.cleanupSession(sessionID)
 
+			// Release the per-session state of the request handler
+			if notifier, ok := h.requestHandler.(sessionEventNotifier); ok {
+				notifier.onSessionTerminated(sessionID)
+			}
+
 			// Return success response
 			h.sendEmptyResponse(w, http.StatusOK, nil)
 			return
@@ -474,12 +753,17 @@ func (h *httpServerHandler) handleGet(ctx context.Context, w http.ResponseWriter
 	}
 
 	// Get session
-	session, ok := h.sessionManager.getSession(sessionID)
+	session, ok := h.getSession(r, sessionID)
 	if !ok {
 		http.Error(w, "Session not found", http.StatusNotFound)
 		return
 	}
 
+	if err := h.checkProtocolVersion(r, session); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Check if streaming is supported
 	flusher, ok := w.(http.Flusher)
 	if !ok {
@@ -529,15 +813,27 @@ func (h *httpServerHandler) handleGet(ctx context.Context, w http.ResponseWriter
 	// Wait for connection to close
 	<-connCtx.Done()
 
-	// Clean up connection
+	// Clean up connection, unless it was already replaced by a new one
 	h.getSSEConnectionsLock.Lock()
-	delete(h.getSSEConnections, session.GetID())
+	if h.getSSEConnections[session.GetID()] == conn {
+		delete(h.getSSEConnections, session.GetID())
+	}
 	h.getSSEConnectionsLock.Unlock()
+
+	// Wait for messages being written, the writer must not be used after the handler returns
+	conn.writeLock.Lock()
+	conn.closed = true
+	conn.writeLock.Unlock()
 	h.logger.Infof("GET SSE connection closed, session ID: %s", session.GetID())
 }
 
 // Send notification through GET SSE
 func (h *httpServerHandler) sendNotificationToGetSSE(sessionID string, notification *JSONRPCNotification) error {
+	return h.sendMessageToGetSSE(sessionID, notification)
+}
+
+// Send a JSON-RPC message (notification or server-initiated request) through GET SSE
+func (h *httpServerHandler) sendMessageToGetSSE(sessionID string, message interface{}) error {
 	h.getSSEConnectionsLock.RLock()
 	conn, ok := h.getSSEConnections[sessionID]
 	h.getSSEConnectionsLock.RUnlock()
@@ -548,11 +844,14 @@ func (h *httpServerHandler) sendNotificationToGetSSE(sessionID string, notificat
 
 	conn.writeLock.Lock()
 	defer conn.writeLock.Unlock()
+	if conn.closed {
+		return fmt.Errorf("%w: %s", ErrSessionNotFound, sessionID)
+	}
 
-	// Use SSE responder to send notification
-	eventID, err := conn.sseResponder.sendNotification(conn.writer, notification)
+	// Use SSE responder to send message
+	eventID, err := conn.sseResponder.sendNotification(conn.writer, message)
 	if err != nil {
-		return fmt.Errorf("failed to send notification via SSE: %w", err)
+		return fmt.Errorf("failed to send message via SSE: %w", err)
 	}
 
 	// Update last event ID
@@ -560,6 +859,39 @@ func (h *httpServerHandler) sendNotificationToGetSSE(sessionID string, notificat
 	return nil
 }
 
+// newClientRequestSender creates a sender for server-initiated requests bound to the current POST request.
+// Requests are written to the POST SSE stream when available, otherwise to the session's GET SSE stream.
+func (h *httpServerHandler) newClientRequestSender(session Session, sse *sseNotificationSender) clientRequestSender {
+	sender := &httpClientRequestSender{
+		handler: h,
+		sse:     sse,
+	}
+	if !h.isStateless && session != nil {
+		sender.sessionID = session.GetID()
+	}
+	return sender
+}
+
+// httpClientRequestSender sends server-initiated requests over streamable HTTP
+type httpClientRequestSender struct {
+	handler   *httpServerHandler
+	sessionID string
+	sse       *sseNotificationSender
+}
+
+// sendClientRequest implements clientRequestSender
+func (s *httpClientRequestSender) sendClientRequest(ctx context.Context, method string, params interface{}) (json.RawMessage, error) {
+	return s.handler.clientResponses.sendAndWait(ctx, s.sessionID, method, params, func(req *JSONRPCRequest) error {
+		if s.sse != nil {
+			return s.sse.sendMessage(req)
+		}
+		if s.sessionID == "" || !s.handler.enableGetSSE {
+			return ErrClientRequestNotSupported
+		}
+		return s.handler.sendMessageToGetSSE(s.sessionID, req)
+	})
+}
+
 // Handle SSE stream resumption
 func (h *httpServerHandler) handleStreamResumption(ctx context.Context, conn *getSSEConnection, sessionID string) {
 	// Get session
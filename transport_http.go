@@ -59,6 +59,12 @@ var (
 	// ErrInvalidContentType is returned when the content type is not supported
 	ErrInvalidContentType = errors.New("invalid content type")
 
+	// ErrUnsupportedProtocolVersion is returned when the MCP-Protocol-Version header is invalid or unsupported
+	ErrUnsupportedProtocolVersion = errors.New("unsupported protocol version")
+
+	// ErrBatchNotSupported is returned when a JSON-RPC batch is received but not allowed
+	ErrBatchNotSupported = errors.New("JSON-RPC batching is not supported")
+
 	// ErrSessionNotFound is returned when a requested session cannot be found
 	ErrSessionNotFound = errors.New("session not found")
 
@@ -57,6 +57,10 @@ type stdioClientTransport struct {
 	notificationHandlers map[string]NotificationHandler
 	handlersMutex        sync.RWMutex
 
+	requestHandlers  *clientRequestHandlers
+	progressHandlers *clientProgressHandlers
+	interceptors     clientInterceptors
+
 	ctx       context.Context
 	cancel    context.CancelFunc
 	closeOnce sync.Once
@@ -78,6 +82,8 @@ func newStdioClientTransport(serverParams StdioServerParameters, options ...stdi
 		timeout:              30 * time.Second, // Default timeout.
 		pendingRequests:      make(map[int64]chan *json.RawMessage),
 		notificationHandlers: make(map[string]NotificationHandler),
+		requestHandlers:      newClientRequestHandlers(),
+		progressHandlers:     newClientProgressHandlers(),
 		ctx:                  ctx,
 		cancel:               cancel,
 		logger:               GetDefaultLogger(),
@@ -261,19 +267,38 @@ func (t *stdioClientTransport) sendNotification(ctx context.Context, notificatio
 	return nil
 }
 
-// sendResponse sends a response (for server mode, not typically used in client).
+// sendResponse sends a response to a server-initiated request.
 func (t *stdioClientTransport) sendResponse(ctx context.Context, resp *JSONRPCResponse) error {
+	return t.sendMessage(resp)
+}
+
+// sendMessage writes a JSON-RPC message to the server.
+func (t *stdioClientTransport) sendMessage(message interface{}) error {
 	if t.closed.Load() {
 		return fmt.Errorf("transport is closed")
 	}
 
 	t.requestMutex.Lock()
-	err := t.encoder.Encode(resp)
+	err := t.encoder.Encode(message)
 	t.requestMutex.Unlock()
 
 	return err
 }
 
+// handleServerRequest dispatches a server-initiated request and writes the response back.
+func (t *stdioClientTransport) handleServerRequest(rawMessage json.RawMessage) {
+	var req JSONRPCRequest
+	if err := json.Unmarshal(rawMessage, &req); err != nil {
+		t.logger.Errorf("Error unmarshaling server request: %v", err)
+		return
+	}
+
+	reply := t.requestHandlers.handle(t.ctx, &req)
+	if err := t.sendMessage(reply); err != nil {
+		t.logger.Errorf("Error sending response for server request %s: %v", req.Method, err)
+	}
+}
+
 // readLoop continuously reads messages from stdout.
 func (t *stdioClientTransport) readLoop() {
 	defer func() {
@@ -306,6 +331,8 @@ func (t *stdioClientTransport) readLoop() {
 			t.handleErrorResponse(rawMessage)
 		case JSONRPCMessageTypeNotification:
 			t.handleNotification(rawMessage)
+		case JSONRPCMessageTypeRequest:
+			go t.handleServerRequest(rawMessage)
 		default:
 			t.logger.Warnf("Unexpected message type: %s", msgType)
 		}
@@ -417,21 +444,37 @@ func (t *stdioClientTransport) handleNotification(rawMessage json.RawMessage) {
 		return
 	}
 
+	if err := t.interceptors.handleNotification(&notification, t.deliverNotification); err != nil {
+		t.logger.Debugf("Error handling notification %s: %v", notification.Method, err)
+	}
+}
+
+// deliverNotification delivers a notification to its handler.
+func (t *stdioClientTransport) deliverNotification(notification *JSONRPCNotification) error {
+	// Deliver progress to the handler of the request it belongs to.
+	t.progressHandlers.handle(notification)
+
 	t.handlersMutex.RLock()
 	handler, exists := t.notificationHandlers[notification.Method]
 	t.handlersMutex.RUnlock()
 
 	if !exists {
 		t.logger.Debugf("No handler for notification method: %s", notification.Method)
-		return
+		return nil
 	}
 
 	// Call handler in goroutine to avoid blocking.
 	go func() {
-		if err := handler(&notification); err != nil {
+		if err := handler(notification); err != nil {
 			t.logger.Debugf("Error handling notification %s: %v", notification.Method, err)
 		}
 	}()
+	return nil
+}
+
+// setInterceptors sets the interceptors of received notifications.
+func (t *stdioClientTransport) setInterceptors(interceptors clientInterceptors) {
+	t.interceptors = interceptors
 }
 
 // stderrLoop reads and logs stderr output.
@@ -481,6 +524,21 @@ func (t *stdioClientTransport) unregisterNotificationHandler(method string) {
 	t.handlersMutex.Unlock()
 }
 
+// registerRequestHandler registers a handler for server-initiated requests.
+func (t *stdioClientTransport) registerRequestHandler(method string, handler RequestHandler) {
+	t.requestHandlers.register(method, handler)
+}
+
+// unregisterRequestHandler removes a handler for server-initiated requests.
+func (t *stdioClientTransport) unregisterRequestHandler(method string) {
+	t.requestHandlers.unregister(method)
+}
+
+// registerProgressHandler registers a handler for the progress notifications of a request.
+func (t *stdioClientTransport) registerProgressHandler(token ProgressToken, handler ProgressHandler) func() {
+	return t.progressHandlers.register(token, handler)
+}
+
 // close closes the transport and terminates the process.
 func (t *stdioClientTransport) close() error {
 	if !t.closed.CompareAndSwap(false, true) {
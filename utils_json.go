@@ -41,6 +41,15 @@ func parseGetPromptResultFromJSON(rawMessage *json.RawMessage) (*GetPromptResult
 	return &result, nil
 }
 
+// parseCompleteResultFromJSON parses a raw JSON message into a CompleteResult
+func parseCompleteResultFromJSON(rawMessage *json.RawMessage) (*CompleteResult, error) {
+	var result CompleteResult
+	if err := json.Unmarshal(*rawMessage, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal CompleteResult: %v", err)
+	}
+	return &result, nil
+}
+
 // parseListResourcesResultFromJSON parses a raw JSON message into a ListResourcesResult
 func parseListResourcesResultFromJSON(rawMessage *json.RawMessage) (*ListResourcesResult, error) {
 	var result ListResourcesResult
@@ -50,6 +59,15 @@ func parseListResourcesResultFromJSON(rawMessage *json.RawMessage) (*ListResourc
 	return &result, nil
 }
 
+// parseListResourceTemplatesResultFromJSON parses a raw JSON message into a ListResourceTemplatesResult
+func parseListResourceTemplatesResultFromJSON(rawMessage *json.RawMessage) (*ListResourceTemplatesResult, error) {
+	var result ListResourceTemplatesResult
+	if err := json.Unmarshal(*rawMessage, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ListResourceTemplatesResult: %v", err)
+	}
+	return &result, nil
+}
+
 // parseReadResourceResultFromJSON parses a raw JSON message into a ReadResourceResult
 func parseReadResourceResultFromJSON(rawMessage *json.RawMessage) (*ReadResourceResult, error) {
 	// Parse JSON object using internal utility function.
@@ -59,6 +77,7 @@ func parseReadResourceResultFromJSON(rawMessage *json.RawMessage) (*ReadResource
 	}
 
 	result := &ReadResourceResult{}
+	result.Meta = utils.ExtractMap(data, "_meta")
 
 	// Parse contents array
 	if contentsArray := utils.ExtractArray(data, "contents"); contentsArray != nil {
@@ -91,8 +110,13 @@ func parseReadResourceResultFromJSON(rawMessage *json.RawMessage) (*ReadResource
 	return result, nil
 }
 
-// parseListToolsResultFromJSON parses a raw JSON message into a ListToolsResult
-func parseListToolsResultFromJSON(rawMessage *json.RawMessage) (*ListToolsResult, error) {
+// parseListToolsResultFromJSON parses a raw JSON message into a ListToolsResult,
+// invalid optional fields of tools are logged with logger, or the default logger if nil, and left empty
+func parseListToolsResultFromJSON(rawMessage *json.RawMessage, logger Logger) (*ListToolsResult, error) {
+	if logger == nil {
+		logger = GetDefaultLogger()
+	}
+
 	// Parse JSON object using internal utility function.
 	data, err := utils.ParseJSONObject(rawMessage)
 	if err != nil {
@@ -102,6 +126,7 @@ func parseListToolsResultFromJSON(rawMessage *json.RawMessage) (*ListToolsResult
 	result := &ListToolsResult{
 		Tools: []Tool{},
 	}
+	result.Meta = utils.ExtractMap(data, "_meta")
 
 	// Parse tools array
 	if toolsArray := utils.ExtractArray(data, "tools"); toolsArray != nil {
@@ -115,41 +140,37 @@ func parseListToolsResultFromJSON(rawMessage *json.RawMessage) (*ListToolsResult
 
 				tool := Tool{
 					Name:           name,
+					Title:          utils.ExtractString(toolMap, "title"),
 					Description:    description,
 					RawInputSchema: rawSchema,
 				}
 
 				// Convert RawInputSchema to InputSchema object.
 				if rawSchema != nil {
-					// First try to parse rawSchema directly to openapi3.Schema.
-					var schema openapi3.Schema
-					err := json.Unmarshal(rawSchema, &schema)
-					if err == nil {
-						tool.InputSchema = &schema
+					schema, err := parseToolSchema(rawSchema)
+					if err != nil {
+						continue
+					}
+					tool.InputSchema = schema
+				}
+
+				// Parse the optional output schema.
+				if outputSchema := utils.ExtractMap(toolMap, "outputSchema"); outputSchema != nil {
+					schema, err := parseToolOutputSchema(outputSchema)
+					if err != nil {
+						logger.Warnf("Ignoring invalid output schema of tool %s: %v", name, err)
 					} else {
-						// If the direct parsing fails, it may be due to type mismatch.
-						// Use a more flexible processing method.
-						var rawSchemaMap map[string]interface{}
-						if jsonErr := json.Unmarshal(rawSchema, &rawSchemaMap); jsonErr != nil {
-							// If the map cannot be parsed, skip processing.
-							continue
-						}
-
-						// Process special field types.
-						handleSchemaNumberBoolFields(rawSchemaMap)
-
-						// Re-serialize and deserialize.
-						fixedData, jsonErr := json.Marshal(rawSchemaMap)
-						if jsonErr != nil {
-							continue
-						}
-
-						var fixedSchema openapi3.Schema
-						if jsonErr := json.Unmarshal(fixedData, &fixedSchema); jsonErr != nil {
-							continue
-						}
-
-						tool.InputSchema = &fixedSchema
+						tool.OutputSchema = schema
+					}
+				}
+
+				// Parse the optional annotations.
+				if annotations := utils.ExtractMap(toolMap, "annotations"); annotations != nil {
+					toolAnnotations, err := parseToolAnnotations(annotations)
+					if err != nil {
+						logger.Warnf("Ignoring invalid annotations of tool %s: %v", name, err)
+					} else {
+						tool.Annotations = toolAnnotations
 					}
 				}
 
@@ -167,6 +188,59 @@ func parseListToolsResultFromJSON(rawMessage *json.RawMessage) (*ListToolsResult
 	return result, nil
 }
 
+// parseToolOutputSchema parses the output schema of a tool
+func parseToolOutputSchema(outputSchema map[string]interface{}) (*openapi3.Schema, error) {
+	rawOutputSchema, err := json.Marshal(outputSchema)
+	if err != nil {
+		return nil, err
+	}
+	return parseToolSchema(rawOutputSchema)
+}
+
+// parseToolAnnotations parses the annotations of a tool
+func parseToolAnnotations(annotations map[string]interface{}) (*ToolAnnotations, error) {
+	data, err := json.Marshal(annotations)
+	if err != nil {
+		return nil, err
+	}
+	toolAnnotations := &ToolAnnotations{}
+	if err := json.Unmarshal(data, toolAnnotations); err != nil {
+		return nil, err
+	}
+	return toolAnnotations, nil
+}
+
+// parseToolSchema parses a raw JSON schema of a tool into an openapi3.Schema
+func parseToolSchema(rawSchema json.RawMessage) (*openapi3.Schema, error) {
+	// First try to parse rawSchema directly to openapi3.Schema.
+	var schema openapi3.Schema
+	if err := json.Unmarshal(rawSchema, &schema); err == nil {
+		return &schema, nil
+	}
+
+	// If the direct parsing fails, it may be due to type mismatch.
+	// Use a more flexible processing method.
+	var rawSchemaMap map[string]interface{}
+	if err := json.Unmarshal(rawSchema, &rawSchemaMap); err != nil {
+		return nil, err
+	}
+
+	// Process special field types.
+	handleSchemaNumberBoolFields(rawSchemaMap)
+
+	// Re-serialize and deserialize.
+	fixedData, err := json.Marshal(rawSchemaMap)
+	if err != nil {
+		return nil, err
+	}
+
+	var fixedSchema openapi3.Schema
+	if err := json.Unmarshal(fixedData, &fixedSchema); err != nil {
+		return nil, err
+	}
+	return &fixedSchema, nil
+}
+
 // processExclusiveField exclusiveMaximum/exclusiveMinimum field, convert number type to boolean type.
 func processExclusiveField(schema map[string]interface{}, field string) {
 	if value, exists := schema[field]; exists {